@@ -0,0 +1,533 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/teamtailor"
+	"github.com/amalgamated-tools/jobscraping/pkg/atsfake"
+	"github.com/amalgamated-tools/jobscraping/pkg/watch"
+	"github.com/amalgamated-tools/jobscraping/pkg/watchlist"
+)
+
+func runScrape(ctx context.Context, a *app, args []string) error {
+	if len(args) != 2 {
+		return ErrUsage
+	}
+
+	loader, err := a.loader(ats.Target{ATS: args[0], Company: args[1]})
+	if err != nil {
+		return fmt.Errorf("error looking up loader: %w", err)
+	}
+
+	return a.scrapeCompany(ctx, strings.ToLower(args[0]), loader, args[1])
+}
+
+func runJob(ctx context.Context, a *app, args []string) error {
+	if len(args) != 3 {
+		return ErrUsage
+	}
+
+	loader, err := a.loader(ats.Target{ATS: args[0], Company: args[1]})
+	if err != nil {
+		return fmt.Errorf("error looking up loader: %w", err)
+	}
+
+	return a.scrapeJob(ctx, strings.ToLower(args[0]), loader, args[1], args[2])
+}
+
+func runScrapeURL(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	target, err := ats.ParseURL(args[0])
+	if err != nil {
+		return fmt.Errorf("error parsing URL: %w", err)
+	}
+
+	loader, err := a.loader(target)
+	if err != nil {
+		return fmt.Errorf("error looking up loader: %w", err)
+	}
+
+	if target.JobID == "" {
+		return a.scrapeCompany(ctx, target.ATS, loader, target.Company)
+	}
+
+	return a.scrapeJob(ctx, target.ATS, loader, target.Company, target.JobID)
+}
+
+func runScrapeAll(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	list, err := watchlist.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("error loading watchlist: %w", err)
+	}
+
+	ctx, err = a.withCompanyInfoCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	companies := list.Enabled()
+	scraped := make([]*models.Job, 0)
+	failed := 0
+
+	// a company that fails is reported and skipped, so one broken board doesn't stop the rest
+	for _, company := range companies {
+		if ctx.Err() != nil {
+			return fmt.Errorf("error scraping companies: %w", ctx.Err())
+		}
+
+		jobs, err := a.collectCompany(ctx, company.ATS, company.Slug, company.Stream(ctx))
+		if err != nil {
+			failed++
+
+			_, _ = fmt.Fprintf(a.stderr, "warning: %s: %v\n", company, err)
+
+			continue
+		}
+
+		scraped = append(scraped, jobs...)
+	}
+
+	err = a.writeJobs(scraped)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", ErrCompaniesFailed, failed, len(companies))
+	}
+
+	return nil
+}
+
+func runWatch(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	list, err := watchlist.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("error loading watchlist: %w", err)
+	}
+
+	store, err := a.openStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	// each scrape gets a cache of its own, so a failed lookup is retried by the next one
+	companyInfoOpts, err := a.companyInfoOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = watch.New(store, list, a.writeEvent, watch.WithCompanyInfoCache(companyInfoOpts...)).Run(ctx)
+	if err != nil {
+		return fmt.Errorf("error watching companies: %w", err)
+	}
+
+	return nil
+}
+
+func runDiscover(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	targets, err := ats.Discover(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("error discovering job boards: %w", err)
+	}
+
+	return a.writeTargets(targets)
+}
+
+// scrapeCompany scrapes every job posted by a company, saving them as they arrive when a
+// database is configured, and writes them out.
+func (a *app) scrapeCompany(ctx context.Context, source string, loader ats.Loader, company string) error {
+	ctx, err := a.withCompanyInfoCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	scraped, err := a.collectCompany(ctx, source, company, ats.Stream(ctx, loader, company))
+	if err != nil {
+		return err
+	}
+
+	return a.writeJobs(scraped)
+}
+
+// collectCompany drains a company scrape, saving the jobs as they arrive when a database
+// is configured and warning about the ones that failed, and returns the scraped jobs.
+func (a *app) collectCompany(ctx context.Context, source, company string, jobs iter.Seq2[*models.Job, error]) ([]*models.Job, error) {
+	scraped := make([]*models.Job, 0)
+
+	var scrapeErr error
+
+	// jobs are saved as they arrive, and collected for the output, which needs all of them
+	stream := func(yield func(*models.Job, error) bool) {
+		for job, err := range jobs {
+			var jobErr *models.JobError
+
+			switch {
+			case err == nil:
+				scraped = append(scraped, job)
+			case errors.As(err, &jobErr):
+				a.warnJobError(jobErr)
+			default:
+				scrapeErr = err
+			}
+
+			if !yield(job, err) {
+				return
+			}
+		}
+	}
+
+	err := a.syncCompany(ctx, source, company, stream)
+	if scrapeErr != nil {
+		return nil, fmt.Errorf("error scraping company %s: %w", company, scrapeErr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scraped, nil
+}
+
+// scrapeJob scrapes a single job posting, saving it when a database is configured, and
+// writes it out. A job the ATS reports gone is closed in the database.
+func (a *app) scrapeJob(ctx context.Context, source string, loader ats.Loader, company, jobID string) error {
+	ctx, err := a.withCompanyInfoCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	job, err := loader.ScrapeJob(ctx, company, jobID)
+	if err != nil {
+		if errors.Is(err, models.ErrJobGone) {
+			a.closeJob(ctx, source, jobID)
+		}
+
+		return fmt.Errorf("error scraping job %s for company %s: %w", jobID, company, err)
+	}
+
+	err = a.saveJobs(ctx, company, []*models.Job{job})
+	if err != nil {
+		return err
+	}
+
+	return a.writeJob(job)
+}
+
+func runCompanyInfo(ctx context.Context, a *app, args []string) error {
+	if len(args) != 2 {
+		return ErrUsage
+	}
+
+	company, err := ats.ScrapeCompanyInfo(ctx, args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("error scraping company info for %s: %w", args[1], err)
+	}
+
+	return a.writeCompany(company)
+}
+
+func runHistory(ctx context.Context, a *app, args []string) error {
+	if len(args) != 2 {
+		return ErrUsage
+	}
+
+	store, err := a.openStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := store.JobHistory(ctx, strings.ToLower(args[0]), args[1])
+	if err != nil {
+		return fmt.Errorf("error reading job history: %w", err)
+	}
+
+	return a.writeRevisions(revisions)
+}
+
+func runDB(ctx context.Context, a *app, args []string) error {
+	if len(args) != 2 || args[0] != "migrate" {
+		return ErrUsage
+	}
+
+	store, err := a.openStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch args[1] {
+	case "up":
+		applied, err := store.MigrateUp(ctx)
+		if err != nil {
+			return fmt.Errorf("error applying migrations: %w", err)
+		}
+
+		return a.writeStrings(applied)
+	case "down":
+		version, err := store.MigrateDown(ctx)
+		if err != nil {
+			return fmt.Errorf("error rolling back migration: %w", err)
+		}
+
+		return a.writeStrings([]string{version})
+	case "status":
+		statuses, err := store.MigrationStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("error reading migration status: %w", err)
+		}
+
+		return a.writeMigrationStatus(statuses)
+	default:
+		return ErrUsage
+	}
+}
+
+// fakeServerTimeout bounds how long fake-server waits for request headers, and for
+// requests in flight when it is stopped.
+const fakeServerTimeout = 5 * time.Second
+
+func runFakeServer(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("fake-server", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fixturesPath := fs.String("fixtures", "", "JSON fixtures to serve instead of the bundled ones")
+	latency := fs.Duration("latency", 0, "delay every response by this long")
+	rateLimitEvery := fs.Int("429-every", 0, "answer every nth request with a 429, starting with the first (0 disables)")
+	malformedEvery := fs.Int("malformed-every", 0, "cut the body of every nth response in half, starting with the first (0 disables)")
+
+	err := fs.Parse(args)
+	if err != nil || fs.NArg() > 1 {
+		return ErrUsage
+	}
+
+	addr := "127.0.0.1:8080"
+	if fs.NArg() == 1 {
+		addr = fs.Arg(0)
+	}
+
+	fixtures, err := atsfake.DefaultFixtures()
+	if *fixturesPath != "" {
+		fixtures, err = atsfake.LoadFixturesFile(*fixturesPath)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error loading fixtures: %w", err)
+	}
+
+	faults := make([]atsfake.Fault, 0)
+
+	if *latency > 0 {
+		faults = append(faults, atsfake.Fault{Latency: *latency})
+	}
+
+	if *rateLimitEvery > 0 {
+		faults = append(faults, atsfake.Fault{Every: *rateLimitEvery, Status: http.StatusTooManyRequests, RetryAfter: time.Second})
+	}
+
+	if *malformedEvery > 0 {
+		faults = append(faults, atsfake.Fault{Every: *malformedEvery, Malformed: true})
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           atsfake.New(fixtures, atsfake.WithFaults(faults...)),
+		ReadHeaderTimeout: fakeServerTimeout,
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fakeServerTimeout)
+		defer cancel()
+
+		err := server.Shutdown(shutdownCtx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error shutting down fake ATS server", slog.Any("error", err))
+		}
+	})
+	defer stop()
+
+	err = a.writeStrings([]string{"http://" + listener.Addr().String()})
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	err = server.Serve(listener)
+	if !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("error serving fake ATS APIs: %w", err)
+	}
+
+	return nil
+}
+
+func runJobs(ctx context.Context, a *app, args []string) error {
+	if len(args) != 2 {
+		return ErrUsage
+	}
+
+	store, err := a.openStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := store.ListCompanyJobs(ctx, strings.ToLower(args[0]), args[1])
+	if err != nil {
+		return fmt.Errorf("error listing stored jobs: %w", err)
+	}
+
+	return a.writeStoredJobs(jobs)
+}
+
+func runListATS(_ context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return ErrUsage
+	}
+
+	return a.writeStrings(ats.Names())
+}
+
+func runVersion(_ context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return ErrUsage
+	}
+
+	_, err := fmt.Fprintln(a.stdout, Commit)
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// saveJobs persists scraped jobs when a database is configured.
+func (a *app) saveJobs(ctx context.Context, company string, jobs []*models.Job) error {
+	if a.dbURL == "" {
+		return nil
+	}
+
+	store, err := a.openStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = store.SaveJobs(ctx, company, jobs)
+	if err != nil {
+		return fmt.Errorf("error saving jobs: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Saved jobs", slog.Int("count", len(jobs)))
+
+	return nil
+}
+
+// loader returns the loader for a target, reading a Teamtailor company from the API when
+// -teamtailor-api-key is set.
+func (a *app) loader(target ats.Target) (ats.Loader, error) {
+	if a.teamtailorAPIKey != "" && strings.EqualFold(target.ATS, teamtailor.Source) {
+		return ats.KeyedLoader(target.ATS, target.Company, a.teamtailorAPIKey) //nolint:wrapcheck // the callers wrap it
+	}
+
+	return target.Loader() //nolint:wrapcheck // the callers wrap it
+}
+
+// withCompanyInfoCache attaches a company info cache to ctx. When a database is configured
+// the cache is backed by it, so company info is only fetched again after -company-info-ttl.
+func (a *app) withCompanyInfoCache(ctx context.Context) (context.Context, error) {
+	opts, err := a.companyInfoOptions(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	return companyinfo.WithCache(ctx, companyinfo.New(opts...)), nil
+}
+
+// companyInfoOptions returns the options company info caches are made with, backing them
+// with the database when one is configured.
+func (a *app) companyInfoOptions(ctx context.Context) ([]companyinfo.Option, error) {
+	if a.dbURL == "" || a.companyInfoTTL <= 0 {
+		return nil, nil
+	}
+
+	store, err := a.openStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []companyinfo.Option{companyinfo.WithStore(store, a.companyInfoTTL)}, nil
+}
+
+// closeJob marks a stored job as closed once the ATS reports it gone. It only logs
+// failures since the caller is already reporting the scrape error.
+func (a *app) closeJob(ctx context.Context, source, sourceID string) {
+	if a.dbURL == "" {
+		return
+	}
+
+	store, err := a.openStore(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error opening database", slog.Any("error", err))
+		return
+	}
+
+	closed, err := store.CloseJob(ctx, source, sourceID, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "Error closing job", slog.String("source", source), slog.String("job_id", sourceID), slog.Any("error", err))
+		return
+	}
+
+	if closed {
+		slog.InfoContext(ctx, "Closed job that no longer exists", slog.String("source", source), slog.String("job_id", sourceID))
+	}
+}
+
+// syncCompany saves a company scrape as it streams in when a database is configured,
+// closing jobs that are no longer listed unless the scrape was partial. Without a
+// database the stream is still drained.
+func (a *app) syncCompany(ctx context.Context, source, company string, jobs iter.Seq2[*models.Job, error]) error {
+	if a.dbURL == "" {
+		// the stream only runs the scrape when drained; runScrape reports its errors
+		_, _ = models.CollectResult(jobs)
+
+		return nil
+	}
+
+	store, err := a.openStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.SyncCompanySeq(ctx, source, company, jobs, time.Now())
+	if err != nil {
+		return fmt.Errorf("error saving jobs: %w", err)
+	}
+
+	return nil
+}
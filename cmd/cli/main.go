@@ -3,23 +3,345 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
-	"github.com/amalgamated-tools/jobscraping/pkg/ats/ashby"
-	_ "modernc.org/sqlite"
+	"github.com/amalgamated-tools/jobscraping/pkg/atsfake"
+	"github.com/amalgamated-tools/jobscraping/pkg/cassette"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+	"github.com/amalgamated-tools/jobscraping/pkg/storage"
 )
 
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+var (
+	// Commit is the git commit the binary was built from, set via -ldflags.
+	Commit = "dev"
+
+	// ErrUsage is returned when a command is invoked with the wrong arguments.
+	ErrUsage = errors.New("usage error")
+	// ErrCompaniesFailed is returned by scrape-all when some of the companies failed to scrape.
+	ErrCompaniesFailed = errors.New("companies failed to scrape")
+)
+
+// app holds the global options shared by every command.
+type app struct {
+	stdout  io.Writer
+	stderr  io.Writer
+	format  string
+	timeout time.Duration
+	dbURL   string
+	store   *storage.Store
+
+	// companyInfoTTL is how long company information saved in the database is reused.
+	companyInfoTTL time.Duration
+	// teamtailorAPIKey is the API key of the Teamtailor company given on the command line.
+	teamtailorAPIKey string
+}
+
+// command is a single CLI subcommand.
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
 func main() {
-	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+
+	stop()
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	a := &app{
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	fs := flag.NewFlagSet("cli", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&a.format, "format", "text", "output format: text or json")
+	fs.DurationVar(&a.timeout, "timeout", 0, "overall timeout for the command, e.g. 30s (0 disables)")
+	fs.StringVar(&a.dbURL, "db", os.Getenv("DATABASE_URL"), "SQLite database to save scraped jobs to, e.g. sqlite:db/jobscraping.db (defaults to $DATABASE_URL)")
+	fs.DurationVar(&a.companyInfoTTL, "company-info-ttl", 24*time.Hour, "reuse company info saved in the database for this long (0 disables)")
+	fs.StringVar(&a.teamtailorAPIKey, "teamtailor-api-key", os.Getenv("TEAMTAILOR_API_KEY"), "read the Teamtailor company given to scrape, job or scrape-url from the API with this key (defaults to $TEAMTAILOR_API_KEY)")
+	httpTimeout := fs.Duration("http-timeout", helpers.DefaultClientConfig.Timeout, "timeout for each HTTP request attempt (0 disables)")
+	retries := fs.Int("retries", helpers.DefaultClientConfig.MaxRetries, "number of times to retry HTTP requests that fail with a 429, a 5xx or a transport error")
+	rateLimits := rateLimitFlag{}
+	fs.Var(&rateLimits, "rate-limit", "per-host request rate as [ats=]per_second[:burst], e.g. greenhouse=2:4 (repeatable; without an ATS it sets the default)")
+	workers := fs.Int("workers", helpers.DefaultConcurrency, "number of jobs to fetch concurrently from ATSes that need one request per job")
+	record := fs.String("record", "", "record every HTTP request and response to this cassette directory")
+	replay := fs.String("replay", "", "serve HTTP requests from this cassette directory instead of the network")
+	fakeATS := fs.String("fake-ats", "", "send ATS requests to a fake ATS server started with fake-server, e.g. http://127.0.0.1:8080")
+	logLevel := fs.String("log-level", "warn", "log level: debug, info, warn or error")
+	fs.Usage = func() { usage(fs) }
+
+	err := fs.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+
+		return exitUsage
+	}
+
+	var level slog.Level
+
+	err = level.UnmarshalText([]byte(*logLevel))
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "invalid -log-level %q\n", *logLevel)
+		return exitUsage
+	}
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(stderr, &slog.HandlerOptions{Level: level})))
+
+	if a.format != "text" && a.format != "json" {
+		_, _ = fmt.Fprintf(stderr, "invalid -format %q\n", a.format)
+		return exitUsage
+	}
+
+	if *retries < 0 {
+		_, _ = fmt.Fprintf(stderr, "invalid -retries %d\n", *retries)
+		return exitUsage
+	}
+
+	if *workers < 1 {
+		_, _ = fmt.Fprintf(stderr, "invalid -workers %d\n", *workers)
+		return exitUsage
+	}
+
+	if *record != "" && *replay != "" {
+		_, _ = fmt.Fprintln(stderr, "-record and -replay can't be used together")
+		return exitUsage
+	}
+
+	if *fakeATS != "" && *replay != "" {
+		_, _ = fmt.Fprintln(stderr, "-fake-ats and -replay can't be used together")
+		return exitUsage
+	}
+
+	if fs.NArg() == 0 {
+		usage(fs)
+		return exitUsage
+	}
+
+	cmd, ok := findCommand(fs.Arg(0))
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n", fs.Arg(0))
+		usage(fs)
+
+		return exitUsage
+	}
+
+	clientConfig := helpers.DefaultClientConfig
+	clientConfig.Timeout = *httpTimeout
+	clientConfig.MaxRetries = *retries
+	clientOptions := append([]helpers.ClientOption{helpers.WithClientConfig(clientConfig)}, rateLimits...)
+
+	httpClient, err := newHTTPClient(*record, *replay, *fakeATS)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+
+	helpers.SetClient(helpers.NewClient(httpClient, clientOptions...))
+
+	ctx = helpers.WithConcurrency(ctx, *workers)
+
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+
+	defer a.close()
+
+	err = cmd.run(ctx, a, fs.Args()[1:])
+	if err != nil {
+		if errors.Is(err, ErrUsage) {
+			_, _ = fmt.Fprintf(stderr, "usage: cli [flags] %s %s\n", cmd.name, cmd.args)
+			return exitUsage
+		}
+
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+
+		return exitError
+	}
+
+	return exitOK
+}
+
+// rateLimitFlag collects -rate-limit values as client options.
+type rateLimitFlag []helpers.ClientOption
+
+func (f *rateLimitFlag) String() string {
+	return ""
+}
+
+func (f *rateLimitFlag) Set(value string) error {
+	ats, spec, perATS := strings.Cut(value, "=")
+	if !perATS {
+		spec = ats
+	}
+
+	perSecond, burst, hasBurst := strings.Cut(spec, ":")
+
+	limit := helpers.RateLimit{}
+
+	var err error
+
+	limit.PerSecond, err = strconv.ParseFloat(perSecond, 64)
+	if err != nil {
+		return fmt.Errorf("invalid requests per second %q: %w", perSecond, err)
+	}
+
+	limit.Burst = max(int(limit.PerSecond), 1)
+
+	if hasBurst {
+		limit.Burst, err = strconv.Atoi(burst)
+		if err != nil {
+			return fmt.Errorf("invalid burst %q: %w", burst, err)
+		}
+	}
+
+	if perATS {
+		*f = append(*f, helpers.WithATSRateLimit(strings.ToLower(ats), limit))
+	} else {
+		*f = append(*f, helpers.WithRateLimit(limit))
+	}
+
+	return nil
+}
+
+// newHTTPClient returns an HTTP client that replays a cassette directory, or one that
+// sends ATS requests to a fake ATS server and records to a cassette directory when those
+// are given, or a plain client when none of them are.
+func newHTTPClient(record, replay, fakeATS string) (*http.Client, error) {
+	if replay != "" {
+		replayer, err := cassette.NewReplayer(os.DirFS(replay))
+		if err != nil {
+			return nil, fmt.Errorf("error opening cassette: %w", err)
+		}
+
+		return &http.Client{Transport: replayer}, nil
+	}
+
+	var transport http.RoundTripper
+
+	if fakeATS != "" {
+		fake, err := atsfake.NewTransport(fakeATS, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error using fake ATS server: %w", err)
+		}
+
+		transport = fake
+	}
+
+	if record != "" {
+		recorder, err := cassette.NewRecorder(record, transport)
+		if err != nil {
+			return nil, fmt.Errorf("error opening cassette: %w", err)
+		}
+
+		transport = recorder
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// openStore opens the database given by -db, reusing it across calls.
+func (a *app) openStore(ctx context.Context) (*storage.Store, error) {
+	if a.store != nil {
+		return a.store, nil
+	}
+
+	if a.dbURL == "" {
+		return nil, fmt.Errorf("%w: -db or $DATABASE_URL is required", ErrUsage)
+	}
 
-	jobs, err := ashby.ScrapeCompany(context.Background(), "1password")
+	store, err := storage.Open(ctx, a.dbURL)
 	if err != nil {
-		slog.Error("Error scraping jobs from Ashby", slog.Any("error", err))
-		return
+		return nil, fmt.Errorf("error opening database: %w", err)
 	}
 
-	for _, job := range jobs {
-		slog.Info("Scraped job", slog.String("title", job.Title), slog.String("company", job.Company.Name))
+	a.store = store
+
+	return store, nil
+}
+
+// close releases any resources opened by a command.
+func (a *app) close() {
+	if a.store != nil {
+		err := a.store.Close()
+		if err != nil {
+			slog.Error("Error closing database", slog.Any("error", err))
+		}
 	}
 }
+
+func commands() []command {
+	return []command{
+		{name: "scrape", args: "<ats> <company>", summary: "scrape every job posted by a company", run: runScrape},
+		{name: "job", args: "<ats> <company> <job-id>", summary: "scrape a single job posting", run: runJob},
+		{name: "scrape-all", args: "<watchlist.yaml>", summary: "scrape every company listed in a watchlist file", run: runScrapeAll},
+		{name: "watch", args: "<watchlist.yaml>", summary: "scrape watchlist companies on their schedules, saving to -db and printing new, updated and closed jobs", run: runWatch},
+		{name: "scrape-url", args: "<url>", summary: "scrape the job board or job posting a URL points at", run: runScrapeURL},
+		{name: "discover", args: "<url>", summary: "find the ATS job boards a company's homepage or careers page embeds or links to", run: runDiscover},
+		{name: "company-info", args: "<ats> <company>", summary: "scrape company information", run: runCompanyInfo},
+		{name: "jobs", args: "<ats> <company>", summary: "list stored jobs with first seen, last seen and closed dates", run: runJobs},
+		{name: "history", args: "<ats> <job-id>", summary: "show how a stored job posting changed over time", run: runHistory},
+		{name: "db", args: "migrate up|down|status", summary: "apply, roll back or list database migrations", run: runDB},
+		{name: "fake-server", args: "[-fixtures file] [-latency d] [-429-every n] [-malformed-every n] [addr]", summary: "serve fake ATS APIs from fixtures for offline scrapes with -fake-ats", run: runFakeServer},
+		{name: "list-ats", summary: "list the supported ATSs", run: runListATS},
+		{name: "version", summary: "print the build commit", run: runVersion},
+	}
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+
+	return command{}, false
+}
+
+func usage(fs *flag.FlagSet) {
+	out := fs.Output()
+
+	_, _ = fmt.Fprintln(out, "usage: cli [flags] <command> [args]")
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintln(out, "commands:")
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands() {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+	}
+
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintln(out, "flags:")
+	fs.PrintDefaults()
+}
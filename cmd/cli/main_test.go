@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/teamtailor"
+	"github.com/amalgamated-tools/jobscraping/pkg/atsfake"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+)
+
+func Test_runListATS(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(t.Context(), []string{"list-ats"}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("run() code = %v, want %v, stderr = %s", code, exitOK, stderr.String())
+	}
+
+	if !strings.Contains(stdout.String(), "greenhouse\n") {
+		t.Errorf("run() stdout = %q, want it to contain greenhouse", stdout.String())
+	}
+}
+
+func Test_runUsageErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"nope"}},
+		{name: "missing args", args: []string{"scrape", "greenhouse"}},
+		{name: "bad format", args: []string{"-format", "xml", "list-ats"}},
+		{name: "bad log level", args: []string{"-log-level", "loud", "list-ats"}},
+		{name: "record and replay", args: []string{"-record", "a", "-replay", "b", "list-ats"}},
+		{name: "fake ATS and replay", args: []string{"-fake-ats", "http://127.0.0.1:8080", "-replay", "b", "list-ats"}},
+		{name: "fake server extra args", args: []string{"fake-server", "127.0.0.1:0", "extra"}},
+		{name: "scrape URL missing URL", args: []string{"scrape-url"}},
+		{name: "scrape all extra args", args: []string{"scrape-all", "a.yaml", "b.yaml"}},
+		{name: "watch missing watchlist", args: []string{"watch"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			code := run(t.Context(), tt.args, &stdout, &stderr)
+			if code != exitUsage {
+				t.Errorf("run(%v) code = %v, want %v", tt.args, code, exitUsage)
+			}
+		})
+	}
+}
+
+func Test_runUnknownATS(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(t.Context(), []string{"scrape", "nope", "acme"}, &stdout, &stderr)
+	if code != exitError {
+		t.Errorf("run() code = %v, want %v", code, exitError)
+	}
+
+	if !strings.Contains(stderr.String(), "unknown ATS") {
+		t.Errorf("run() stderr = %q, want it to mention unknown ATS", stderr.String())
+	}
+}
+
+//nolint:paralleltest // run replaces the package-level HTTP client, so this must not overlap other runs
+func Test_runReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	board := `{
+  "request": {"method": "GET", "url": "https://boards-api.greenhouse.io/v1/boards/acme/jobs?content=true&pay_transparency=true"},
+  "responses": [{"status": 200, "json": {"jobs": [{"id": 1, "title": "Engineer", "absolute_url": "https://job-boards.greenhouse.io/acme/jobs/1"}]}}]
+}`
+
+	err := os.WriteFile(filepath.Join(dir, "board.json"), []byte(board), 0o600)
+	if err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	// company info was not recorded, which only costs a warning
+	code := run(t.Context(), []string{"-replay", dir, "-retries", "0", "-format", "json", "scrape", "greenhouse", "acme"}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("run() code = %v, want %v, stderr = %s", code, exitOK, stderr.String())
+	}
+
+	if !strings.Contains(stdout.String(), `"title": "Engineer"`) {
+		t.Errorf("run() stdout = %q, want the replayed job", stdout.String())
+	}
+
+	if !strings.Contains(stderr.String(), "not recorded") {
+		t.Errorf("run() stderr = %q, want a warning about the missing company info", stderr.String())
+	}
+}
+
+//nolint:paralleltest // run replaces the package-level HTTP client, so this must not overlap other runs
+func Test_runFakeATS(t *testing.T) {
+	fixtures, err := atsfake.DefaultFixtures()
+	if err != nil {
+		t.Fatalf("DefaultFixtures() error = %v", err)
+	}
+
+	server := httptest.NewServer(atsfake.New(fixtures))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(t.Context(), []string{"-fake-ats", server.URL, "-format", "json", "scrape", "lever", "acme"}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("run() code = %v, want %v, stderr = %s", code, exitOK, stderr.String())
+	}
+
+	if !strings.Contains(stdout.String(), `"title": "Senior Backend Engineer"`) || !strings.Contains(stdout.String(), `"name": "Acme Corporation"`) {
+		t.Errorf("run() stdout = %q, want the fixture jobs", stdout.String())
+	}
+}
+
+//nolint:paralleltest // run replaces the package-level HTTP client, so this must not overlap other runs
+func Test_runScrapeURL(t *testing.T) {
+	fixtures, err := atsfake.DefaultFixtures()
+	if err != nil {
+		t.Fatalf("DefaultFixtures() error = %v", err)
+	}
+
+	server := httptest.NewServer(atsfake.New(fixtures))
+	defer server.Close()
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://apply.workable.com/acme/j/1002/", want: `"title": "Account Executive"`},
+		{url: "https://acme.bamboohr.com/careers", want: `"title": "Product Design Intern"`},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+
+		code := run(t.Context(), []string{"-fake-ats", server.URL, "-format", "json", "scrape-url", tt.url}, &stdout, &stderr)
+		if code != exitOK {
+			t.Fatalf("run(%s) code = %v, want %v, stderr = %s", tt.url, code, exitOK, stderr.String())
+		}
+
+		if !strings.Contains(stdout.String(), tt.want) {
+			t.Errorf("run(%s) stdout = %q, want it to contain %s", tt.url, stdout.String(), tt.want)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(t.Context(), []string{"scrape-url", "https://example.com/careers"}, &stdout, &stderr)
+	if code != exitError || !strings.Contains(stderr.String(), "not a supported ATS") {
+		t.Errorf("run() for an unsupported URL code = %v, stderr = %q, want %v and an unsupported URL error", code, stderr.String(), exitError)
+	}
+}
+
+//nolint:paralleltest // run replaces the package-level HTTP client, so this must not overlap other runs
+func Test_runScrapeAll(t *testing.T) {
+	fixtures, err := atsfake.DefaultFixtures()
+	if err != nil {
+		t.Fatalf("DefaultFixtures() error = %v", err)
+	}
+
+	server := httptest.NewServer(atsfake.New(fixtures))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "watchlist.yaml")
+
+	err = os.WriteFile(path, []byte(`companies:
+  - ats: workable
+    slug: globex
+    name: Globex Corporation
+  - url: https://jobs.lever.co/acme
+  - ats: ashby
+    slug: initech
+  - ats: rippling
+    slug: acme
+    disabled: true
+`), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	// initech isn't in the fixtures, so it fails without stopping the other companies
+	code := run(t.Context(), []string{"-fake-ats", server.URL, "-format", "json", "scrape-all", path}, &stdout, &stderr)
+	if code != exitError {
+		t.Errorf("run() code = %v, want %v", code, exitError)
+	}
+
+	if !strings.Contains(stderr.String(), "ashby/initech") || !strings.Contains(stderr.String(), "1 of 3") {
+		t.Errorf("run() stderr = %q, want a warning about ashby/initech", stderr.String())
+	}
+
+	var jobs []*models.Job
+
+	err = json.Unmarshal(stdout.Bytes(), &jobs)
+	if err != nil {
+		t.Fatalf("run() stdout isn't a JSON list of jobs: %v", err)
+	}
+
+	sources := map[string]int{}
+	for _, job := range jobs {
+		sources[job.Source]++
+
+		if job.Source == "workable" && (job.Company == nil || job.Company.Name != "Globex Corporation") {
+			t.Errorf("run() workable company = %+v, want the name override", job.Company)
+		}
+	}
+
+	if sources["workable"] != 1 || sources["lever"] != 3 || sources["rippling"] != 0 {
+		t.Errorf("run() jobs per source = %v, want 1 from workable and 3 from lever", sources)
+	}
+}
+
+func Test_appLoader(t *testing.T) {
+	t.Parallel()
+
+	a := &app{teamtailorAPIKey: "public-key"}
+
+	loader, err := a.loader(ats.Target{ATS: "teamtailor", Company: "acme"})
+	if err != nil {
+		t.Fatalf("loader(teamtailor) error = %v", err)
+	}
+
+	if loader != teamtailor.New(teamtailor.WithAPIKey("acme", "public-key")) {
+		t.Errorf("loader(teamtailor) = %+v, want a loader with the key for acme", loader)
+	}
+
+	// the key is only for Teamtailor, so other ATSes get their usual loader
+	loader, err = a.loader(ats.Target{ATS: "lever", Company: "acme"})
+	if err != nil {
+		t.Fatalf("loader(lever) error = %v", err)
+	}
+
+	if want, _ := ats.Get("lever"); loader != want {
+		t.Errorf("loader(lever) = %+v, want %+v", loader, want)
+	}
+}
+
+func Test_rateLimitFlag(t *testing.T) {
+	t.Parallel()
+
+	var limits rateLimitFlag
+
+	for _, value := range []string{"3", "Greenhouse=1.5:4", "lever=0"} {
+		err := limits.Set(value)
+		if err != nil {
+			t.Fatalf("Set(%q) error = %v", value, err)
+		}
+	}
+
+	client := helpers.NewClient(nil, limits...)
+
+	tests := map[string]helpers.RateLimit{
+		"ashby":      helpers.DefaultATSRateLimits["ashby"],
+		"bamboo":     {PerSecond: 3, Burst: 3},
+		"greenhouse": {PerSecond: 1.5, Burst: 4},
+		"lever":      {PerSecond: 0, Burst: 1},
+	}
+
+	for ats, want := range tests {
+		if got := client.RateLimitFor(ats); got != want {
+			t.Errorf("RateLimitFor(%s) = %v, want %v", ats, got, want)
+		}
+	}
+
+	for _, value := range []string{"fast", "greenhouse=2:many"} {
+		if limits.Set(value) == nil {
+			t.Errorf("Set(%q) error = nil, want an error", value)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"text/tabwriter"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/storage"
+	"github.com/amalgamated-tools/jobscraping/pkg/watch"
+)
+
+// writeJSON encodes v as indented JSON to stdout.
+func (a *app) writeJSON(v any) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+
+	err := enc.Encode(v)
+	if err != nil {
+		return fmt.Errorf("error encoding JSON output: %w", err)
+	}
+
+	return nil
+}
+
+// writeJobs writes a list of jobs in the selected output format.
+func (a *app) writeJobs(jobs []*models.Job) error {
+	if a.format == "json" {
+		return a.writeJSON(jobs)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SOURCE\tID\tTITLE\tLOCATION\tTYPE\tURL")
+
+	for _, job := range jobs {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			job.Source, job.SourceID, job.Title, job.Location, job.LocationType, job.URL)
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// warnJobError reports a job that failed to scrape on stderr, so it doesn't mix with the output.
+func (a *app) warnJobError(jobErr *models.JobError) {
+	_, _ = fmt.Fprintf(a.stderr, "warning: %v\n", jobErr)
+}
+
+// writeJob writes a single job in the selected output format.
+func (a *app) writeJob(job *models.Job) error {
+	if a.format == "json" {
+		return a.writeJSON(job)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Source:\t%s\n", job.Source)
+	_, _ = fmt.Fprintf(w, "ID:\t%s\n", job.SourceID)
+	_, _ = fmt.Fprintf(w, "Title:\t%s\n", job.Title)
+
+	if job.Company != nil {
+		_, _ = fmt.Fprintf(w, "Company:\t%s\n", job.Company.Name)
+	}
+
+	_, _ = fmt.Fprintf(w, "Department:\t%s\n", job.DepartmentRaw)
+	_, _ = fmt.Fprintf(w, "Location:\t%s\n", job.Location)
+	_, _ = fmt.Fprintf(w, "Location Type:\t%s\n", job.LocationType)
+	_, _ = fmt.Fprintf(w, "Employment Type:\t%s\n", job.EmploymentType)
+
+	if job.MinCompensation != 0 || job.MaxCompensation != 0 {
+		_, _ = fmt.Fprintf(w, "Compensation:\t%s %.0f - %.0f\n", job.CompensationUnit, job.MinCompensation, job.MaxCompensation)
+	}
+
+	if !job.DatePosted.IsZero() {
+		_, _ = fmt.Fprintf(w, "Posted:\t%s\n", job.DatePosted.Format("2006-01-02"))
+	}
+
+	_, _ = fmt.Fprintf(w, "URL:\t%s\n", job.URL)
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// writeCompany writes company information in the selected output format.
+func (a *app) writeCompany(company *models.Company) error {
+	if a.format == "json" {
+		return a.writeJSON(company)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", company.Name)
+	_, _ = fmt.Fprintf(w, "Homepage:\t%s\n", company.Homepage.String())
+	_, _ = fmt.Fprintf(w, "Logo:\t%s\n", company.Logo.String())
+
+	if company.Description != nil {
+		_, _ = fmt.Fprintf(w, "Description:\t%s\n", *company.Description)
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// writeStoredJobs writes stored jobs along with their lifecycle.
+func (a *app) writeStoredJobs(jobs []*storage.StoredJob) error {
+	if a.format == "json" {
+		return a.writeJSON(jobs)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTITLE\tFIRST SEEN\tLAST SEEN\tCLOSED\tOPEN FOR")
+
+	for _, job := range jobs {
+		closed := ""
+		if job.ClosedAt != nil {
+			closed = job.ClosedAt.Format(time.DateTime)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			job.Job.SourceID,
+			job.Job.Title,
+			job.FirstSeenAt.Format(time.DateTime),
+			job.LastSeenAt.Format(time.DateTime),
+			closed,
+			job.OpenFor().Round(time.Hour),
+		)
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// writeRevisions writes a job's revision history, one block per revision.
+func (a *app) writeRevisions(revisions []*storage.Revision) error {
+	if a.format == "json" {
+		return a.writeJSON(revisions)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+
+	for i, revision := range revisions {
+		_, _ = fmt.Fprintf(w, "Revision %d\t%s\t%s\n", i+1, revision.CreatedAt.Format(time.DateTime), revision.Job.Title)
+
+		for _, change := range revision.Changes {
+			if change.Field == "description" {
+				_, _ = fmt.Fprintf(w, "  %s:\tchanged (%d -> %d characters)\n", change.Field, len(change.Old), len(change.New))
+				continue
+			}
+
+			_, _ = fmt.Fprintf(w, "  %s:\t%q -> %q\n", change.Field, change.Old, change.New)
+		}
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// writeMigrationStatus writes the applied state of every migration.
+func (a *app) writeMigrationStatus(statuses []storage.MigrationStatus) error {
+	if a.format == "json" {
+		return a.writeJSON(statuses)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+
+	for _, status := range statuses {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%t\n", status.Version, status.Name, status.Applied)
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// writeTargets writes the job boards found by discover.
+func (a *app) writeTargets(targets []ats.Target) error {
+	if a.format == "json" {
+		return a.writeJSON(targets)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ATS\tCOMPANY")
+
+	for _, target := range targets {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", target.ATS, target.Company)
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// writeEvent writes a single watch event as a line of text, or of JSON, as it happens.
+// Write errors are only logged since the watch carries on regardless.
+func (a *app) writeEvent(ctx context.Context, event watch.Event) {
+	var err error
+
+	if a.format == "json" {
+		err = json.NewEncoder(a.stdout).Encode(event)
+	} else {
+		job := event.Job.Job
+		_, err = fmt.Fprintf(a.stdout, "%s\t%s\t%s/%s\t%s\t%s\t%s\n",
+			event.Time.Format(time.RFC3339), event.Type, event.Source, event.Company, job.SourceID, job.Title, job.URL)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Error writing watch event", slog.Any("error", err))
+	}
+}
+
+// writeStrings writes one value per line, or a JSON array.
+func (a *app) writeStrings(values []string) error {
+	if a.format == "json" {
+		return a.writeJSON(values)
+	}
+
+	for _, value := range values {
+		_, err := fmt.Fprintln(a.stdout, value)
+		if err != nil {
+			return fmt.Errorf("error writing output: %w", err)
+		}
+	}
+
+	return nil
+}
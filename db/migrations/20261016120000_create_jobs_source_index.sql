@@ -0,0 +1,5 @@
+-- migrate:up
+CREATE UNIQUE INDEX IF NOT EXISTS jobs_source_source_id ON jobs (source, source_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS jobs_source_source_id;
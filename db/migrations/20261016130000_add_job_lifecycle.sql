@@ -0,0 +1,13 @@
+-- migrate:up
+ALTER TABLE jobs ADD COLUMN company VARCHAR(255);
+ALTER TABLE jobs ADD COLUMN first_seen_at DATETIME;
+ALTER TABLE jobs ADD COLUMN last_seen_at DATETIME;
+ALTER TABLE jobs ADD COLUMN closed_at DATETIME;
+CREATE INDEX IF NOT EXISTS jobs_source_company ON jobs (source, company);
+
+-- migrate:down
+DROP INDEX IF EXISTS jobs_source_company;
+ALTER TABLE jobs DROP COLUMN closed_at;
+ALTER TABLE jobs DROP COLUMN last_seen_at;
+ALTER TABLE jobs DROP COLUMN first_seen_at;
+ALTER TABLE jobs DROP COLUMN company;
@@ -0,0 +1,12 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS job_revisions (
+    id INTEGER PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    data JSON NOT NULL,
+    changes JSON,
+    created_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS job_revisions_job_id ON job_revisions (job_id);
+
+-- migrate:down
+DROP TABLE IF EXISTS job_revisions;
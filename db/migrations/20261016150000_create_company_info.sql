@@ -0,0 +1,11 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS company_info (
+    source VARCHAR(255) NOT NULL,
+    company VARCHAR(255) NOT NULL,
+    data JSON NOT NULL,
+    fetched_at DATETIME NOT NULL,
+    PRIMARY KEY (source, company)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS company_info;
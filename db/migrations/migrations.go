@@ -0,0 +1,9 @@
+// Package migrations embeds the dbmate-style SQL migrations so they can be applied from Go.
+package migrations
+
+import "embed"
+
+// FS contains every migration file, named <version>_<name>.sql.
+//
+//go:embed *.sql
+var FS embed.FS
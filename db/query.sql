@@ -9,4 +9,59 @@ ORDER BY id;
 -- name: CreateJob :one
 INSERT INTO jobs (absolute_url, data)
 VALUES (?, ?)
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: UpsertJob :one
+INSERT INTO jobs (absolute_url, data, company, first_seen_at, last_seen_at)
+VALUES (sqlc.arg(absolute_url), sqlc.arg(data), sqlc.arg(company), sqlc.arg(seen_at), sqlc.arg(seen_at))
+ON CONFLICT (source, source_id) DO UPDATE SET
+    absolute_url = excluded.absolute_url,
+    data = excluded.data,
+    company = excluded.company,
+    last_seen_at = excluded.last_seen_at,
+    closed_at = NULL
+RETURNING *;
+
+-- name: GetJobBySource :one
+SELECT * FROM jobs
+WHERE source = ? AND source_id = ? LIMIT 1;
+
+-- name: ListCompanyJobs :many
+SELECT * FROM jobs
+WHERE source = ? AND company = ?
+ORDER BY id;
+
+-- name: CloseMissingJobs :many
+UPDATE jobs SET closed_at = sqlc.arg(closed_at)
+WHERE source = sqlc.arg(source)
+    AND company = sqlc.arg(company)
+    AND closed_at IS NULL
+    AND last_seen_at < sqlc.arg(closed_at)
+RETURNING *;
+
+-- name: CreateJobRevision :one
+INSERT INTO job_revisions (job_id, data, changes, created_at)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: ListJobRevisions :many
+SELECT * FROM job_revisions
+WHERE job_id = ?
+ORDER BY id;
+
+-- name: GetCompanyInfo :one
+SELECT * FROM company_info
+WHERE source = ? AND company = ? LIMIT 1;
+
+-- name: UpsertCompanyInfo :exec
+INSERT INTO company_info (source, company, data, fetched_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (source, company) DO UPDATE SET
+    data = excluded.data,
+    fetched_at = excluded.fetched_at;
+
+-- name: CloseJob :execrows
+UPDATE jobs SET closed_at = sqlc.arg(closed_at)
+WHERE source = sqlc.arg(source)
+    AND source_id = sqlc.arg(source_id)
+    AND closed_at IS NULL;
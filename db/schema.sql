@@ -3,9 +3,30 @@ CREATE TABLE jobs (
     id INTEGER PRIMARY KEY,
     absolute_url VARCHAR(767) NOT NULL UNIQUE,
     data JSON
-, source VARCHAR(255) GENERATED ALWAYS AS (JSON_EXTRACT(data, '$.source')) VIRTUAL, source_id VARCHAR(255) GENERATED ALWAYS AS (JSON_EXTRACT(data, '$.source_id')) VIRTUAL);
+, source VARCHAR(255) GENERATED ALWAYS AS (JSON_EXTRACT(data, '$.source')) VIRTUAL, source_id VARCHAR(255) GENERATED ALWAYS AS (JSON_EXTRACT(data, '$.source_id')) VIRTUAL, company VARCHAR(255), first_seen_at DATETIME, last_seen_at DATETIME, closed_at DATETIME);
+CREATE UNIQUE INDEX jobs_source_source_id ON jobs (source, source_id);
+CREATE INDEX jobs_source_company ON jobs (source, company);
+CREATE TABLE job_revisions (
+    id INTEGER PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    data JSON NOT NULL,
+    changes JSON,
+    created_at DATETIME NOT NULL
+);
+CREATE INDEX job_revisions_job_id ON job_revisions (job_id);
+CREATE TABLE company_info (
+    source VARCHAR(255) NOT NULL,
+    company VARCHAR(255) NOT NULL,
+    data JSON NOT NULL,
+    fetched_at DATETIME NOT NULL,
+    PRIMARY KEY (source, company)
+);
 -- Dbmate schema migrations
 INSERT INTO "schema_migrations" (version) VALUES
   ('20251220202955'),
   ('20251220204205'),
-  ('20251220221021');
+  ('20251220221021'),
+  ('20261016120000'),
+  ('20261016130000'),
+  ('20261016140000'),
+  ('20261016150000');
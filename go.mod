@@ -5,7 +5,10 @@ go 1.25.4
 require (
 	github.com/buger/jsonparser v1.1.1
 	github.com/h2non/gock v1.2.0
+	github.com/robfig/cron/v3 v3.0.1
 	golang.org/x/net v0.47.0
+	golang.org/x/time v0.15.0
+	gopkg.in/yaml.v3 v3.0.1
 	modernc.org/sqlite v1.41.0
 )
 
@@ -4,123 +4,272 @@ package ashby
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"net/url"
 	"strings"
 
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
 	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
 	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
 	"github.com/buger/jsonparser"
 )
 
-var (
-	ashbyCompanyURL = "https://api.ashbyhq.com/posting-api/job-board/%s?includeCompensation=true"
+// Source identifies jobs scraped from Ashby and is the name the loader is registered under.
+const Source = "ashby"
 
-	ashbyJobURL   = "https://jobs.ashbyhq.com/api/non-user-graphql?op=ApiJobPosting"
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Ashby endpoints; use New to point it elsewhere.
+type Loader struct {
+	apiURL  string
+	jobsURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithAPIURL sets the base URL of the posting API, DefaultAPIURL by default.
+func WithAPIURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.apiURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithJobsURL sets the base URL of the hosted job boards and their GraphQL API, DefaultJobsURL by default.
+func WithJobsURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.jobsURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Ashby.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Ashby, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Ashby, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Ashby given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+// ScrapeCompanyInfo scrapes company information for a given company from Ashby.
+func (l Loader) ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return ScrapeCompanyInfo(l.withURLs(ctx), companyName)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.apiURL == "" {
+		l.apiURL = DefaultAPIURL
+	}
+
+	if l.jobsURL == "" {
+		l.jobsURL = DefaultJobsURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultAPIURL is the base URL of the public Ashby posting API.
+	DefaultAPIURL = "https://api.ashbyhq.com"
+	// DefaultJobsURL is the base URL of the hosted Ashby job boards.
+	DefaultJobsURL = "https://jobs.ashbyhq.com"
+
+	ashbyCompanyURL = "%s/posting-api/job-board/%s?includeCompensation=true"
+
+	ashbyJobURL   = "%s/api/non-user-graphql?op=ApiJobPosting"
 	ashbyJobQuery = `{"query":"{\n\tjobPosting(organizationHostedJobsPageName: \"%s\", jobPostingId: \"%s\") {\ncompensationPhilosophyHtml\ncompensationTiers {\n  id\n  title\n  tierSummary\n}\ncompensationTierSummary\ndepartmentName\ndescriptionHtml\nemploymentType\nid\nisConfidential\nisListed\nlinkedData\nlocationAddress\nlocationName\npublishedDate\nscrapeableCompensationSalarySummary\nsecondaryLocationNames\nteamNames\ntitle\nworkplaceType\n\t}\n}"}`
 
-	ashbyCompanyInfoURL   = "https://jobs.ashbyhq.com/api/non-user-graphql?op=ApiOrganizationFromHostedJobsPageName"
+	ashbyCompanyInfoURL   = "%s/api/non-user-graphql?op=ApiOrganizationFromHostedJobsPageName"
 	ashbyCompanyInfoQuery = "{\"query\":\"query ApiOrganizationFromHostedJobsPageName {\\n  organization: organizationFromHostedJobsPageName(\\n    organizationHostedJobsPageName: \\\"%s\\\"\\n    searchContext: JobBoard\\n  ) {\\n    ...OrganizationParts\\n    __typename\\n  }\\n}\\n\\nfragment OrganizationParts on Organization {\\n  name\\n  publicWebsite\\n  timezone\\n  theme {\\n    logoSquareImageUrl\\n  }\\n  __typename\\n}\"}"
 )
 
 // ScrapeCompany scrapes all jobs for a given company from Ashby ATS.
 func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
-	slog.DebugContext(ctx, "Scraping company", slog.String("ats", "ashby"), slog.String("company_name", companyName))
+	result, err := ScrapeCompanyResult(ctx, companyName)
 
-	company, err := ScrapeCompanyInfo(ctx, companyName)
-	if err != nil {
-		slog.ErrorContext(ctx, "Error scraping company info for Ashby company", slog.String("company_name", companyName), slog.Any("error", err))
-		return nil, fmt.Errorf("error scraping company info for Ashby company: %w", err)
-	}
+	return result.Jobs, err
+}
 
-	jobs := make([]*models.Job, 0)
+// ScrapeCompanyResult scrapes all jobs for a given company from Ashby ATS, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
 
-	// The URL is like https://api.ashbyhq.com/posting-api/job-board/{companyName}?includeCompensation=true
-	companyURL := fmt.Sprintf(ashbyCompanyURL, companyName)
+// StreamCompany scrapes all jobs for a given company from Ashby ATS, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
 
-	// Get the JSON from the company job board endpoint
-	body, err := helpers.GetJSON(ctx, companyURL, nil)
-	if err != nil {
-		slog.ErrorContext(ctx, "Error getting JSON from Ashby job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
-		return jobs, fmt.Errorf("error getting JSON from Ashby job board endpoint: %w", err)
-	}
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "ashby"), slog.String("company_name", companyName))
 
-	_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
-		id, err := jsonparser.GetString(value, "id")
+		ctx = companyinfo.Ensure(ctx)
+
+		company, err := companyInfo(ctx, companyName)
 		if err != nil {
-			slog.ErrorContext(ctx, "Error parsing job id from Ashby job board endpoint", slog.Any("error", err))
+			slog.ErrorContext(ctx, "Error scraping company info for Ashby company", slog.String("company_name", companyName), slog.Any("error", err))
+			yield(nil, fmt.Errorf("error scraping company info for Ashby company: %w", err))
+
 			return
 		}
 
-		job, err := ScrapeJob(ctx, companyName, id)
+		// The URL is like https://api.ashbyhq.com/posting-api/job-board/{companyName}?includeCompensation=true
+		companyURL := fmt.Sprintf(ashbyCompanyURL, endpoints(ctx).apiURL, companyName)
+
+		// Get the JSON from the company job board endpoint
+		body, err := helpers.GetJSON(ctx, companyURL, nil)
 		if err != nil {
-			slog.ErrorContext(ctx, "Error scraping individual job", slog.String("job_id", job.SourceID), slog.Any("error", err))
+			slog.ErrorContext(ctx, "Error getting JSON from Ashby job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
+			yield(nil, fmt.Errorf("error getting JSON from Ashby job board endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
+
 			return
 		}
 
-		if job.Company.Name == "" {
-			job.Company = company
-		}
+		stopped := false
 
-		slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
-		jobs = append(jobs, job)
-	}, "jobs")
-	if err != nil {
-		slog.ErrorContext(ctx, "Error parsing jobs array from Ashby job board endpoint", slog.Any("error", err))
-		return jobs, fmt.Errorf("error parsing jobs array: %w", err)
-	}
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			if stopped {
+				return
+			}
 
-	return jobs, nil
+			id, err := jsonparser.GetString(value, "id")
+			if err != nil {
+				slog.ErrorContext(ctx, "Error parsing job id from Ashby job board endpoint", slog.Any("error", err))
+				stopped = !yield(nil, &models.JobError{Stage: models.StageList, Err: fmt.Errorf("error parsing job id from Ashby job board endpoint: %w", err)})
+
+				return
+			}
+
+			job, err := ScrapeJob(ctx, companyName, id)
+			if err != nil {
+				slog.ErrorContext(ctx, "Error scraping individual job", slog.String("job_id", id), slog.Any("error", err))
+				stopped = !yield(nil, &models.JobError{JobID: id, Stage: models.StageDetail, Err: err})
+
+				return
+			}
+
+			if job.Company.Name == "" {
+				job.Company = company
+			}
+
+			slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
+			stopped = !yield(job, nil)
+		}, "jobs")
+		if err != nil && !stopped {
+			slog.ErrorContext(ctx, "Error parsing jobs array from Ashby job board endpoint", slog.Any("error", err))
+			yield(nil, fmt.Errorf("error parsing jobs array: %w", err))
+		}
+	}
 }
 
 // ScrapeJob scrapes an individual job from Ashby ATS given the company name and job ID.
 func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "ashby"), slog.String("company_name", companyName), slog.String("job_id", jobID))
 	payload := strings.NewReader(
 		fmt.Sprintf(ashbyJobQuery, companyName, jobID),
 	)
 
+	jobURL := fmt.Sprintf(ashbyJobURL, endpoints(ctx).jobsURL)
+
 	bodyText, err := helpers.PostJSON(
 		ctx,
-		ashbyJobURL,
+		jobURL,
 		payload,
 		nil,
 	)
 	if err != nil {
-		slog.ErrorContext(ctx, "Error posting JSON to Ashby job endpoint", slog.String("url", ashbyJobURL), slog.Any("error", err))
+		slog.ErrorContext(ctx, "Error posting JSON to Ashby job endpoint", slog.String("url", jobURL), slog.Any("error", err))
 		return nil, fmt.Errorf("error posting JSON to Ashby job endpoint: %w", err)
 	}
 
+	// Ashby answers with a null posting rather than a 404 once a job is removed
+	_, dataType, _, _ := jsonparser.Get(bodyText, "data", "jobPosting")
+	if dataType == jsonparser.Null {
+		return nil, fmt.Errorf("%w: %s/%s", models.ErrJobGone, companyName, jobID)
+	}
+
 	job, err := parseAshbyJob(ctx, bodyText)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error parsing Ashby job from individual job endpoint", slog.String("job_id", jobID), slog.Any("error", err))
 		return nil, fmt.Errorf("error parsing Ashby job: %w", err)
 	}
 
-	job.URL = fmt.Sprintf("https://jobs.ashbyhq.com/%s/%s", companyName, jobID)
+	job.URL = fmt.Sprintf("%s/%s/%s", endpoints(ctx).jobsURL, companyName, jobID)
 
 	return job, nil
 }
 
+// companyInfo returns the company information for companyName through the company info
+// cache carried by ctx, if any.
+func companyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return companyinfo.Lookup(ctx, Source, companyName, func(ctx context.Context) (*models.Company, error) {
+		return ScrapeCompanyInfo(ctx, companyName)
+	})
+}
+
 // ScrapeCompanyInfo scrapes company information and jobs for a given company from Ashby ATS.
 func ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	slog.DebugContext(ctx, "Scraping company info", slog.String("ats", "ashby"), slog.String("company_name", companyName))
 
 	payload := strings.NewReader(
 		fmt.Sprintf(ashbyCompanyInfoQuery, companyName),
 	)
 
+	companyInfoURL := fmt.Sprintf(ashbyCompanyInfoURL, endpoints(ctx).jobsURL)
+
 	bodyText, err := helpers.PostJSON(
 		ctx,
-		ashbyCompanyInfoURL,
+		companyInfoURL,
 		payload,
 		nil,
 	)
 	if err != nil {
-		slog.ErrorContext(ctx, "Error posting JSON to Ashby company info endpoint", slog.String("url", ashbyCompanyInfoURL), slog.Any("error", err))
+		slog.ErrorContext(ctx, "Error posting JSON to Ashby company info endpoint", slog.String("url", companyInfoURL), slog.Any("error", err))
 		return nil, fmt.Errorf("error posting JSON to Ashby company info endpoint: %w", err)
 	}
 
+	_, dataType, _, _ := jsonparser.Get(bodyText, "data", "organization")
+	if dataType == jsonparser.Null {
+		return nil, fmt.Errorf("%w: %s", models.ErrBoardNotFound, companyName)
+	}
+
 	company := models.NewCompany()
 
 	err = jsonparser.ObjectEach(bodyText, func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
@@ -155,7 +304,7 @@ func ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company
 }
 
 func parseAshbyJob(ctx context.Context, data []byte) (*models.Job, error) {
-	job := models.NewJob("ashby", data)
+	job := models.NewJob(Source, data)
 
 	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
 		switch string(key) {
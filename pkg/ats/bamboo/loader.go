@@ -4,72 +4,235 @@ package bamboo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"net/url"
 	"strings"
 
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
 	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
 	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
 	"github.com/buger/jsonparser"
 )
 
+// Source identifies jobs scraped from BambooHR and is the name the loader is registered under.
+const Source = "bamboo"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public BambooHR endpoints; use New to point it elsewhere.
+type Loader struct {
+	companyURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithCompanyURL sets the base URL of a company's careers site, with %s standing for the company name, DefaultCompanyURL by default.
+func WithCompanyURL(format string) Option {
+	return func(l *Loader) {
+		l.companyURL = strings.TrimSuffix(format, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// DefaultCompanyURL is the base URL of a company's BambooHR careers site, with %s standing
+// for the company name.
+const DefaultCompanyURL = "https://%s.bamboohr.com"
+
+// careersURL returns the URL of path on companyName's careers site.
+func careersURL(ctx context.Context, companyName, path string) string {
+	return fmt.Sprintf(endpoints(ctx).companyURL, companyName) + path
+}
+
+// ScrapeCompany scrapes all jobs for a given company from BambooHR.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from BambooHR, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from BambooHR, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from BambooHR given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+// ScrapeCompanyInfo scrapes company information for a given company from BambooHR.
+func (l Loader) ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return ScrapeCompanyInfo(l.withURLs(ctx), companyName)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.companyURL == "" {
+		l.companyURL = DefaultCompanyURL
+	}
+
+	return l
+}
+
 // ScrapeCompany scrapes all jobs for a given company from BambooHR ATS.
 func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
-	slog.DebugContext(ctx, "Scraping company", slog.String("ats", "bamboo"), slog.String("company_name", companyName))
+	result, err := ScrapeCompanyResult(ctx, companyName)
 
-	jobs := make([]*models.Job, 0)
+	return result.Jobs, err
+}
 
-	companyURL := "https://" + companyName + ".bamboohr.com/careers/list"
+// ScrapeCompanyResult scrapes all jobs for a given company from BambooHR ATS, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
 
-	body, err := helpers.GetJSON(ctx, companyURL, nil)
-	if err != nil {
-		slog.ErrorContext(ctx, "Error getting JSON from BambooHR job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
-		return jobs, fmt.Errorf("error getting JSON from BambooHR job board endpoint: %w", err)
-	}
+// StreamCompany scrapes all jobs for a given company from BambooHR ATS, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "bamboo"), slog.String("company_name", companyName))
+
+		ctx = companyinfo.Ensure(ctx)
 
-	_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
-		jobID, err := jsonparser.GetString(value, "id")
+		companyURL := careersURL(ctx, companyName, "/careers/list")
+
+		body, err := helpers.GetJSON(ctx, companyURL, nil)
 		if err != nil {
-			slog.ErrorContext(ctx, "Error parsing job ID from jobs array", slog.Any("error", err))
+			slog.ErrorContext(ctx, "Error getting JSON from BambooHR job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
+			yield(nil, fmt.Errorf("error getting JSON from BambooHR job board endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
+
 			return
 		}
 
-		job, jerr := ScrapeJob(ctx, companyName, jobID)
-		if jerr != nil {
-			slog.ErrorContext(ctx, "Error parsing BambooHR job from jobs array", slog.Any("error", jerr))
+		jobIDs := make([]string, 0)
+		listErrs := make([]error, 0)
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			jobID, err := jsonparser.GetString(value, "id")
+			if err != nil {
+				slog.ErrorContext(ctx, "Error parsing job ID from jobs array", slog.Any("error", err))
+				listErrs = append(listErrs, &models.JobError{Stage: models.StageList, Err: fmt.Errorf("error parsing job ID from jobs array: %w", err)})
+
+				return
+			}
+
+			jobIDs = append(jobIDs, jobID)
+		}, "result")
+		if err != nil {
+			slog.ErrorContext(ctx, "Error parsing jobs array from BambooHR job board endpoint", slog.Any("error", err))
+			yield(nil, fmt.Errorf("error parsing jobs array: %w", err))
+
 			return
 		}
 
-		if job.Company.Name == "" {
-			company, err := ScrapeCompanyInfo(ctx, companyName)
+		for _, err := range listErrs {
+			if !yield(nil, err) {
+				return
+			}
+		}
+
+		jobs := helpers.FetchEach(ctx, jobIDs, func(ctx context.Context, jobID string) (*models.Job, error) {
+			job, err := scrapeJob(ctx, companyName, jobID)
+			if err != nil {
+				slog.ErrorContext(ctx, "Error parsing BambooHR job from jobs array", slog.String("job_id", jobID), slog.Any("error", err))
+				return nil, &models.JobError{JobID: jobID, Stage: models.StageDetail, Err: err}
+			}
+
+			return job, nil
+		})
+
+		for job, err := range jobs {
+			if err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+
+				if !yield(nil, err) {
+					return
+				}
+
+				continue
+			}
+
+			company, err := companyInfo(ctx, companyName)
 			if err != nil {
 				slog.ErrorContext(ctx, "Error scraping company info for BambooHR job", slog.String("company_name", companyName), slog.Any("error", err))
+
+				if !yield(nil, &models.JobError{JobID: job.SourceID, Stage: models.StageCompanyInfo, Err: err}) {
+					return
+				}
 			} else {
 				job.Company = company
 			}
+
+			slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
+
+			if !yield(job, nil) {
+				return
+			}
 		}
 
-		slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
-		jobs = append(jobs, job)
-	}, "result")
-	if err != nil {
-		slog.ErrorContext(ctx, "Error parsing jobs array from BambooHR job board endpoint", slog.Any("error", err))
-		return jobs, fmt.Errorf("error parsing jobs array: %w", err)
+		if err := ctx.Err(); err != nil {
+			yield(nil, fmt.Errorf("error scraping BambooHR jobs: %w", err))
+		}
 	}
-
-	return jobs, nil
 }
 
 // ScrapeJob scrapes an individual job from BambooHR ATS given the company name and job ID.
 func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
+	job, err := scrapeJob(ctx, companyName, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := companyInfo(ctx, companyName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error scraping company info for BambooHR job", slog.String("company_name", companyName), slog.Any("error", err))
+	} else {
+		job.Company = company
+	}
+
+	return job, nil
+}
+
+// scrapeJob fetches and parses a job posting without its company information.
+func scrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
 	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "bamboo"), slog.String("company_name", companyName), slog.String("job_id", jobID))
 
-	jobURL := fmt.Sprintf("https://%s.bamboohr.com/careers/%s/detail", companyName, jobID)
+	jobURL := careersURL(ctx, companyName, "/careers/"+jobID+"/detail")
 
 	body, err := helpers.GetJSON(ctx, jobURL, nil)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error getting JSON from BambooHR job endpoint", slog.String("url", jobURL), slog.Any("error", err))
-		return nil, fmt.Errorf("error getting JSON from BambooHR job endpoint: %w", err)
+		return nil, fmt.Errorf("error getting JSON from BambooHR job endpoint: %w", helpers.WrapNotFound(err, models.ErrJobGone))
 	}
 
 	job, err := parseBambooJob(ctx, body)
@@ -77,26 +240,29 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 		return nil, fmt.Errorf("failed to parse job %s for company %s: %w", jobID, companyName, err)
 	}
 
-	company, err := ScrapeCompanyInfo(ctx, companyName)
-	if err != nil {
-		slog.ErrorContext(ctx, "Error scraping company info for BambooHR job", slog.String("company_name", companyName), slog.Any("error", err))
-	} else {
-		job.Company = company
-	}
-
 	return job, nil
 }
 
+// companyInfo returns the company information for companyName through the company info
+// cache carried by ctx, if any.
+func companyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return companyinfo.Lookup(ctx, Source, companyName, func(ctx context.Context) (*models.Company, error) {
+		return ScrapeCompanyInfo(ctx, companyName)
+	})
+}
+
 // ScrapeCompanyInfo scrapes company info from BambooHR ATS given the company name.
 func ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	slog.DebugContext(ctx, "Scraping company info", slog.String("ats", "bamboo"), slog.String("company_name", companyName))
 
-	companyInfoURL := fmt.Sprintf("https://%s.bamboohr.com/careers/company-info", companyName)
+	companyInfoURL := careersURL(ctx, companyName, "/careers/company-info")
 
 	bodyText, err := helpers.GetJSON(ctx, companyInfoURL, nil)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error getting JSON from BambooHR company info endpoint", slog.String("url", companyInfoURL), slog.Any("error", err))
-		return nil, fmt.Errorf("error getting JSON from BambooHR company info endpoint: %w", err)
+		return nil, fmt.Errorf("error getting JSON from BambooHR company info endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound))
 	}
 
 	company := models.NewCompany()
@@ -136,7 +302,7 @@ func ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company
 }
 
 func parseBambooJob(ctx context.Context, data []byte) (*models.Job, error) {
-	job := models.NewJob("bamboo", data)
+	job := models.NewJob(Source, data)
 
 	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
 		switch string(key) {
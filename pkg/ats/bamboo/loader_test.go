@@ -3,8 +3,7 @@ package bamboo
 import (
 	"context"
 	_ "embed"
-	"net/http"
-	"net/http/httptest"
+	"errors"
 	"testing"
 
 	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
@@ -18,6 +17,9 @@ var singleJob string
 //go:embed job_list.json
 var jobList string
 
+//go:embed company_info.json
+var companyInfoJSON string
+
 func Test_parseBambooJob(t *testing.T) {
 	t.Parallel()
 
@@ -80,6 +82,12 @@ func TestScrapeCompany(t *testing.T) {
 			JSON(singleJob)
 	}
 
+	// company info is fetched once and shared by every job
+	gock.New("https://testcompany.bamboohr.com").
+		Get("/careers/company-info").
+		Reply(200).
+		JSON(companyInfoJSON)
+
 	jobs, err := ScrapeCompany(context.Background(), "testcompany")
 	if err != nil {
 		t.Fatalf("ScrapeCompany() error = %v", err)
@@ -88,29 +96,96 @@ func TestScrapeCompany(t *testing.T) {
 	if len(jobs) != 3 {
 		t.Errorf("ScrapeCompany() len(jobs) = %v, want 3", len(jobs))
 	}
-}
 
-func TestScrapeJob(t *testing.T) {
-	t.Parallel()
+	gock.New("https://missingcompany.bamboohr.com").
+		Get("/careers/list").
+		Reply(404)
+
+	_, err = ScrapeCompany(context.Background(), "missingcompany")
+	if !errors.Is(err, models.ErrBoardNotFound) {
+		t.Errorf("ScrapeCompany() error = %v, want %v", err, models.ErrBoardNotFound)
+	}
+
+	// a job that fails is reported instead of silently dropped
+	gock.New("https://partialcompany.bamboohr.com").
+		Get("/careers/list").
+		Reply(200).
+		JSON(jobList)
+
+	for _, id := range []string{"25", "35"} {
+		gock.New("https://partialcompany.bamboohr.com").
+			Get("/careers/" + id + "/detail").
+			Reply(200).
+			JSON(singleJob)
+	}
+
+	gock.New("https://partialcompany.bamboohr.com").
+		Get("/careers/34/detail").
+		Reply(404)
+
+	gock.New("https://partialcompany.bamboohr.com").
+		Get("/careers/company-info").
+		Reply(200).
+		JSON(companyInfoJSON)
+
+	result, err := ScrapeCompanyResult(context.Background(), "partialcompany")
+	if err != nil {
+		t.Fatalf("ScrapeCompanyResult() error = %v", err)
+	}
 
-	// Create a test server that returns the single job JSON
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
+	if len(result.Jobs) != 2 || len(result.Errors) != 1 {
+		t.Fatalf("ScrapeCompanyResult() = %d jobs, %d errors, want 2 jobs, 1 error", len(result.Jobs), len(result.Errors))
+	}
+
+	jobErr := result.Errors[0]
+	if jobErr.JobID != "34" || jobErr.Stage != models.StageDetail || !errors.Is(jobErr, models.ErrJobGone) {
+		t.Errorf("ScrapeCompanyResult() error = %v, want job 34 gone at the detail stage", jobErr)
+	}
+
+	// streaming yields jobs one at a time and stops when the caller does
+	gock.New("https://streamcompany.bamboohr.com").
+		Get("/careers/list").
+		Reply(200).
+		JSON(jobList)
+
+	for _, id := range []string{"25", "34", "35"} {
+		gock.New("https://streamcompany.bamboohr.com").
+			Get("/careers/" + id + "/detail").
+			Reply(200).
+			JSON(singleJob)
+	}
 
-		_, err := w.Write([]byte(singleJob))
+	gock.New("https://streamcompany.bamboohr.com").
+		Get("/careers/company-info").
+		Reply(200).
+		JSON(companyInfoJSON)
+
+	streamed := 0
+
+	for job, err := range StreamCompany(helpers.WithConcurrency(context.Background(), 1), "streamcompany") {
 		if err != nil {
-			t.Fatalf("failed to write response: %v", err)
+			t.Fatalf("StreamCompany() error = %v", err)
 		}
-	}))
-	defer server.Close()
 
-	// Set up HTTP client to use test server
-	helpers.SetHTTPClient(server.Client())
+		if job.Company == nil || job.Company.Name == "" {
+			t.Errorf("StreamCompany() job.Company = %v, want company info", job.Company)
+		}
+
+		streamed++
+
+		break
+	}
+
+	if streamed != 1 {
+		t.Errorf("StreamCompany() yielded %d jobs before break, want 1", streamed)
+	}
+}
 
-	defer helpers.ResetHTTPClient()
+func TestScrapeJob(t *testing.T) {
+	t.Parallel()
 
-	// Test the parse function directly since URL is hardcoded in ScrapeJob
+	// the package-level HTTP client is shared with the parallel TestScrapeCompany, so parse
+	// the job directly rather than replacing it
 	job, err := parseBambooJob(context.Background(), []byte(singleJob))
 	if err != nil {
 		t.Fatalf("parseBambooJob() error = %v", err)
@@ -0,0 +1,229 @@
+// Package companyinfo caches company information so loaders fetch it once per company
+// instead of once per job.
+package companyinfo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+)
+
+// Store persists company information between runs.
+type Store interface {
+	// GetCompanyInfo returns the stored company and when it was fetched, or a nil company
+	// if nothing is stored.
+	GetCompanyInfo(ctx context.Context, source, company string) (*models.Company, time.Time, error)
+	// SaveCompanyInfo stores a company fetched at fetchedAt.
+	SaveCompanyInfo(ctx context.Context, source, company string, info *models.Company, fetchedAt time.Time) error
+}
+
+// FetchFunc fetches company information from the ATS.
+type FetchFunc func(ctx context.Context) (*models.Company, error)
+
+// Cache deduplicates company information lookups. Concurrent lookups for the same company
+// share a single fetch. A Cache is safe for concurrent use.
+type Cache struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu      sync.Mutex
+	entries map[key]*entry
+}
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithStore persists fetched company information in store and reuses stored entries
+// that are younger than ttl.
+func WithStore(store Store, ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.store = store
+		c.ttl = ttl
+	}
+}
+
+type key struct {
+	source  string
+	company string
+}
+
+type entry struct {
+	ready   chan struct{}
+	company *models.Company
+	err     error
+}
+
+// New returns an empty in-memory cache.
+func New(opts ...Option) *Cache {
+	c := &Cache{
+		now:     time.Now,
+		entries: make(map[key]*entry),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Get returns the company information for a source and company, calling fetch only if
+// it is neither cached in memory nor stored within the TTL. A failed fetch is never stored,
+// and is remembered for the lifetime of the cache unless the loader calls Forget. The
+// returned company is a copy the caller may modify.
+func (c *Cache) Get(ctx context.Context, source, company string, fetch FetchFunc) (*models.Company, error) {
+	k := key{source: source, company: company}
+
+	c.mu.Lock()
+
+	e, ok := c.entries[k]
+	if !ok {
+		e = &entry{ready: make(chan struct{})}
+		c.entries[k] = e
+	}
+
+	c.mu.Unlock()
+
+	if ok {
+		select {
+		case <-e.ready:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("error waiting for company info for %s/%s: %w", source, company, ctx.Err())
+		}
+
+		if e.err != nil {
+			return nil, e.err
+		}
+
+		return clone(e.company), nil
+	}
+
+	e.company, e.err = c.load(ctx, k, fetch)
+	if e.err != nil && ctx.Err() != nil {
+		// don't let a cancelled run poison the entry for other callers
+		c.mu.Lock()
+		delete(c.entries, k)
+		c.mu.Unlock()
+	}
+
+	close(e.ready)
+
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return clone(e.company), nil
+}
+
+// Forget drops a failed fetch for a source and company, so the next Get fetches again.
+// Loaders whose fetch depends on more than the company, such as a job page, use it to let
+// the next job try its own page. Entries that are still loading or were fetched are kept.
+func (c *Cache) Forget(source, company string) {
+	k := key{source: source, company: company}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[k]
+	if !ok {
+		return
+	}
+
+	select {
+	case <-e.ready:
+		if e.err != nil {
+			delete(c.entries, k)
+		}
+	default:
+	}
+}
+
+func (c *Cache) load(ctx context.Context, k key, fetch FetchFunc) (*models.Company, error) {
+	if c.store != nil {
+		stored, fetchedAt, err := c.store.GetCompanyInfo(ctx, k.source, k.company)
+		switch {
+		case err != nil:
+			slog.ErrorContext(ctx, "Error reading stored company info", slog.String("ats", k.source), slog.String("company_name", k.company), slog.Any("error", err))
+		case stored != nil && c.now().Sub(fetchedAt) < c.ttl:
+			return stored, nil
+		}
+	}
+
+	company, err := fetch(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // loaders already wrap their errors
+	}
+
+	if company == nil {
+		company = models.NewCompany()
+	}
+
+	if c.store != nil {
+		err = c.store.SaveCompanyInfo(ctx, k.source, k.company, company, c.now())
+		if err != nil {
+			slog.ErrorContext(ctx, "Error saving company info", slog.String("ats", k.source), slog.String("company_name", k.company), slog.Any("error", err))
+		}
+	}
+
+	return company, nil
+}
+
+func clone(company *models.Company) *models.Company {
+	copied := *company
+
+	if company.Description != nil {
+		description := *company.Description
+		copied.Description = &description
+	}
+
+	return &copied
+}
+
+type cacheKey struct{}
+
+// WithCache returns a context that makes loaders share cache.
+func WithCache(ctx context.Context, cache *Cache) context.Context {
+	return context.WithValue(ctx, cacheKey{}, cache)
+}
+
+// FromContext returns the cache attached to ctx, if any.
+func FromContext(ctx context.Context) (*Cache, bool) {
+	cache, ok := ctx.Value(cacheKey{}).(*Cache)
+	return cache, ok
+}
+
+// Ensure returns ctx unchanged if it already carries a cache, or a context with a new
+// in-memory cache otherwise. Loaders call it at the start of a company scrape so that a
+// single run never fetches the same company twice.
+func Ensure(ctx context.Context) context.Context {
+	if _, ok := FromContext(ctx); ok {
+		return ctx
+	}
+
+	return WithCache(ctx, New())
+}
+
+// Lookup returns company information through the cache attached to ctx, or calls fetch
+// directly if there is none.
+func Lookup(ctx context.Context, source, company string, fetch FetchFunc) (*models.Company, error) {
+	cache, ok := FromContext(ctx)
+	if !ok {
+		return fetch(ctx) //nolint:wrapcheck // loaders already wrap their errors
+	}
+
+	return cache.Get(ctx, source, company, fetch)
+}
+
+// Forget drops a failed fetch for a source and company from the cache attached to ctx, if
+// any, so the next Lookup fetches again.
+func Forget(ctx context.Context, source, company string) {
+	cache, ok := FromContext(ctx)
+	if ok {
+		cache.Forget(source, company)
+	}
+}
@@ -0,0 +1,203 @@
+package companyinfo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+)
+
+var errFetch = errors.New("fetch failed")
+
+type memoryStore struct {
+	mu        sync.Mutex
+	companies map[string]*models.Company
+	fetchedAt map[string]time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		companies: make(map[string]*models.Company),
+		fetchedAt: make(map[string]time.Time),
+	}
+}
+
+func (m *memoryStore) GetCompanyInfo(_ context.Context, source, company string) (*models.Company, time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.companies[source+"/"+company], m.fetchedAt[source+"/"+company], nil
+}
+
+func (m *memoryStore) SaveCompanyInfo(_ context.Context, source, company string, info *models.Company, fetchedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.companies[source+"/"+company] = info
+	m.fetchedAt[source+"/"+company] = fetchedAt
+
+	return nil
+}
+
+func countingFetch(calls *atomic.Int32, name string) FetchFunc {
+	return func(_ context.Context) (*models.Company, error) {
+		calls.Add(1)
+		time.Sleep(time.Millisecond)
+
+		company := models.NewCompany()
+		company.Name = name
+
+		return company, nil
+	}
+}
+
+func TestCache_Get(t *testing.T) {
+	t.Parallel()
+
+	cache := New()
+
+	var calls atomic.Int32
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Go(func() {
+			company, err := cache.Get(t.Context(), "bamboo", "acme", countingFetch(&calls, "Acme"))
+			if err != nil || company.Name != "Acme" {
+				t.Errorf("Get() = %v, %v, want Acme", company, err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Get() fetched %d times, want 1", calls.Load())
+	}
+
+	company, _ := cache.Get(t.Context(), "bamboo", "acme", countingFetch(&calls, "Acme"))
+	company.Name = "changed"
+
+	company, _ = cache.Get(t.Context(), "bamboo", "acme", countingFetch(&calls, "Acme"))
+	if company.Name != "Acme" {
+		t.Errorf("Get() returned a shared company, Name = %v, want Acme", company.Name)
+	}
+
+	_, err := cache.Get(t.Context(), "bamboo", "other", func(_ context.Context) (*models.Company, error) {
+		return nil, errFetch
+	})
+	if !errors.Is(err, errFetch) {
+		t.Errorf("Get() error = %v, want %v", err, errFetch)
+	}
+}
+
+func TestCache_GetWithStore(t *testing.T) {
+	t.Parallel()
+
+	store := newMemoryStore()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var calls atomic.Int32
+
+	first := New(WithStore(store, time.Hour))
+	first.now = func() time.Time { return now }
+
+	_, err := first.Get(t.Context(), "gem", "acme", countingFetch(&calls, "Acme"))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	// a later run within the TTL reuses the stored company
+	second := New(WithStore(store, time.Hour))
+	second.now = func() time.Time { return now.Add(30 * time.Minute) }
+
+	company, err := second.Get(t.Context(), "gem", "acme", countingFetch(&calls, "Acme v2"))
+	if err != nil || company.Name != "Acme" || calls.Load() != 1 {
+		t.Errorf("Get() within TTL = %v, %v after %d fetches, want Acme after 1", company, err, calls.Load())
+	}
+
+	// once the TTL has passed it is fetched again
+	third := New(WithStore(store, time.Hour))
+	third.now = func() time.Time { return now.Add(2 * time.Hour) }
+
+	company, err = third.Get(t.Context(), "gem", "acme", countingFetch(&calls, "Acme v2"))
+	if err != nil || company.Name != "Acme v2" || calls.Load() != 2 {
+		t.Errorf("Get() after TTL = %v, %v after %d fetches, want Acme v2 after 2", company, err, calls.Load())
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	for range 2 {
+		_, err := Lookup(t.Context(), "gem", "acme", countingFetch(&calls, "Acme"))
+		if err != nil {
+			t.Fatalf("Lookup() error = %v", err)
+		}
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("Lookup() without a cache fetched %d times, want 2", calls.Load())
+	}
+
+	ctx := Ensure(t.Context())
+	if Ensure(ctx) != ctx {
+		t.Errorf("Ensure() replaced an existing cache")
+	}
+
+	for range 2 {
+		_, err := Lookup(ctx, "gem", "acme", countingFetch(&calls, "Acme"))
+		if err != nil {
+			t.Fatalf("Lookup() error = %v", err)
+		}
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("Lookup() with a cache fetched %d times, want 3", calls.Load())
+	}
+}
+
+func TestCache_Forget(t *testing.T) {
+	t.Parallel()
+
+	cache := New()
+
+	var calls atomic.Int32
+
+	failing := func(_ context.Context) (*models.Company, error) {
+		calls.Add(1)
+		return nil, errFetch
+	}
+
+	_, err := cache.Get(t.Context(), "lever", "acme", failing)
+	if !errors.Is(err, errFetch) {
+		t.Fatalf("Get() error = %v, want %v", err, errFetch)
+	}
+
+	// the failure is remembered until it is forgotten
+	_, _ = cache.Get(t.Context(), "lever", "acme", failing)
+	if calls.Load() != 1 {
+		t.Errorf("Get() fetched %d times, want 1", calls.Load())
+	}
+
+	Forget(WithCache(t.Context(), cache), "lever", "acme")
+
+	company, err := cache.Get(t.Context(), "lever", "acme", countingFetch(&calls, "Acme"))
+	if err != nil || company.Name != "Acme" || calls.Load() != 2 {
+		t.Errorf("Get() after Forget() = %v, %v after %d fetches, want Acme after 2", company, err, calls.Load())
+	}
+
+	// a fetched company is kept
+	cache.Forget("lever", "acme")
+
+	_, _ = cache.Get(t.Context(), "lever", "acme", countingFetch(&calls, "Acme"))
+	if calls.Load() != 2 {
+		t.Errorf("Get() after forgetting a fetched company fetched %d times, want 2", calls.Load())
+	}
+}
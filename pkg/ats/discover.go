@@ -0,0 +1,174 @@
+package ats
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+)
+
+// ErrNoBoardFound is returned by Discover when a page doesn't embed or link to a job board
+// on a supported ATS.
+var ErrNoBoardFound = errors.New("no supported ATS job board found")
+
+// scriptURL matches the absolute and protocol-relative URLs in inline scripts, such as the
+// board URLs passed to embed widgets.
+var scriptURL = regexp.MustCompile(`(?:https?:)?//[^\s"'<>\\)]+`)
+
+// Discover fetches a company's homepage or careers page and returns the ATS job boards it
+// embeds or links to: Greenhouse embed scripts, Lever iframes, Ashby embeds, Workable
+// widgets and links to BambooHR, Gem, Rippling or any other supported board. When the page
+// has none, the first link on it to a careers or jobs page on the same site is checked
+// too. A URL that already points at a board is returned without being fetched.
+//
+// The targets are returned in the order they appear, once per board, without job IDs.
+func Discover(ctx context.Context, pageURL string) ([]Target, error) {
+	target, err := ParseURL(pageURL)
+	if err == nil {
+		target.JobID = ""
+		return []Target{target}, nil
+	}
+
+	pageURL = withScheme(pageURL)
+
+	page, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing page URL %s: %w", pageURL, err)
+	}
+
+	targets, careers, err := discoverPage(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targets) == 0 && careers != nil {
+		slog.DebugContext(ctx, "Following careers link", slog.String("url", careers.String()))
+
+		targets, _, err = discoverPage(ctx, careers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w on %s", ErrNoBoardFound, pageURL)
+	}
+
+	return targets, nil
+}
+
+// discoverPage returns the boards a page embeds or links to, along with the first link on
+// it to a careers page on the same site.
+func discoverPage(ctx context.Context, page *url.URL) ([]Target, *url.URL, error) {
+	body, err := helpers.GetHTML(ctx, page.String())
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting careers page", slog.String("url", page.String()), slog.Any("error", err))
+		return nil, nil, fmt.Errorf("error getting page %s: %w", page, err)
+	}
+
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing HTML", slog.String("url", page.String()), slog.Any("error", err))
+		return nil, nil, fmt.Errorf("error parsing HTML: %w", err)
+	}
+
+	targets := make([]Target, 0)
+	seen := make(map[Target]bool)
+
+	add := func(candidate string) {
+		target, err := ParseURL(candidate)
+		if err != nil {
+			return
+		}
+
+		target.JobID = ""
+
+		// slugs are case-insensitive on every supported ATS
+		key := target
+		key.Company = strings.ToLower(key.Company)
+
+		if !seen[key] {
+			seen[key] = true
+			targets = append(targets, target)
+		}
+	}
+
+	var careers *url.URL
+
+	for n := range doc.Descendants() {
+		switch n.Type {
+		case html.ElementNode:
+			for _, attr := range n.Attr {
+				// URLs can be in src and href, or in data attributes such as BambooHR's
+				// data-domain, but never contain spaces
+				if attr.Val != "" && !strings.ContainsAny(attr.Val, " \t\n") {
+					add(attr.Val)
+				}
+			}
+
+			if n.Data == "a" && careers == nil {
+				careers = careersLink(page, n)
+			}
+		case html.TextNode:
+			if n.Parent != nil && n.Parent.Data == "script" {
+				for _, match := range scriptURL.FindAllString(n.Data, -1) {
+					add(match)
+				}
+			}
+		}
+	}
+
+	return targets, careers, nil
+}
+
+// careersLink returns where a link points if it is to a careers or jobs page on the same
+// site as page, or nil.
+func careersLink(page *url.URL, a *html.Node) *url.URL {
+	for _, attr := range a.Attr {
+		if attr.Key != "href" {
+			continue
+		}
+
+		link, err := page.Parse(attr.Val)
+		if err != nil || !sameSite(page, link) || link.Path == page.Path {
+			return nil
+		}
+
+		path := strings.ToLower(link.Path)
+		text := strings.ToLower(textContent(a))
+
+		if strings.Contains(path, "career") || strings.Contains(path, "jobs") ||
+			strings.Contains(text, "career") || strings.Contains(text, "jobs") {
+			link.Fragment = ""
+			return link
+		}
+	}
+
+	return nil
+}
+
+// sameSite reports whether two URLs are on the same host, ignoring a www prefix.
+func sameSite(a, b *url.URL) bool {
+	return strings.TrimPrefix(strings.ToLower(a.Hostname()), "www.") == strings.TrimPrefix(strings.ToLower(b.Hostname()), "www.")
+}
+
+// textContent returns the text inside a node.
+func textContent(n *html.Node) string {
+	var text strings.Builder
+
+	for d := range n.Descendants() {
+		if d.Type == html.TextNode {
+			text.WriteString(d.Data)
+		}
+	}
+
+	return text.String()
+}
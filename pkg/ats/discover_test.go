@@ -0,0 +1,88 @@
+package ats
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+const careersPage = `<!doctype html>
+<html>
+<head>
+  <script src="https://boards.greenhouse.io/embed/job_board/js?for=acme"></script>
+  <script src="https://apply.workable.com/api/v1/widget/accounts/acme-labs"></script>
+  <script>
+    var board = { url: "https://jobs.ashbyhq.com/acme/embed?version=2" };
+  </script>
+</head>
+<body>
+  <div id="grnhse_app"></div>
+  <iframe src="//jobs.lever.co/acme?display=iframe"></iframe>
+  <div id="BambooHR" data-domain="acme.bamboohr.com"></div>
+  <a href="https://boards.greenhouse.io/ACME/jobs/123">Senior Engineer</a>
+  <a href="https://jobs.gem.com/acme-gem">Gem</a>
+  <a href="https://ats.rippling.com/acme/jobs">Rippling</a>
+  <a href="https://example.com/about us">About</a>
+</body>
+</html>`
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/careers", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(careersPage))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><a href="/about">About</a><a href="/careers#open">Join us</a></body></html>`))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><a href="https://other.example.com/careers">Careers</a></body></html>`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	want := []Target{
+		{ATS: "greenhouse", Company: "acme"},
+		{ATS: "workable", Company: "acme-labs"},
+		{ATS: "ashby", Company: "acme"},
+		{ATS: "lever", Company: "acme"},
+		{ATS: "bamboo", Company: "acme"},
+		{ATS: "gem", Company: "acme-gem"},
+		{ATS: "rippling", Company: "acme"},
+	}
+
+	// the homepage only links to the careers page, which is followed
+	for _, pageURL := range []string{server.URL + "/careers", server.URL} {
+		got, err := Discover(t.Context(), pageURL)
+		if err != nil {
+			t.Fatalf("Discover(%s) error = %v", pageURL, err)
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("Discover(%s) = %+v, want %+v", pageURL, got, want)
+		}
+	}
+
+	_, err := Discover(t.Context(), server.URL+"/empty")
+	if !errors.Is(err, ErrNoBoardFound) {
+		t.Errorf("Discover() for a page without boards error = %v, want %v", err, ErrNoBoardFound)
+	}
+}
+
+func TestDiscoverBoardURL(t *testing.T) {
+	t.Parallel()
+
+	got, err := Discover(t.Context(), "https://jobs.lever.co/acme/c4b4c0a6-8d1e-4e5f-9a3b-2f6d7e8a9b0c")
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	want := []Target{{ATS: "lever", Company: "acme"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Discover() = %+v, want %+v", got, want)
+	}
+}
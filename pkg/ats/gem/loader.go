@@ -4,75 +4,200 @@ package gem
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
 	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
 	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
 	"github.com/buger/jsonparser"
 )
 
-var (
+// Source identifies jobs scraped from Gem and is the name the loader is registered under.
+const Source = "gem"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Gem endpoints; use New to point it elsewhere.
+type Loader struct {
+	apiURL  string
+	jobsURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithAPIURL sets the base URL of the job board API, DefaultAPIURL by default.
+func WithAPIURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.apiURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithJobsURL sets the base URL of the hosted job boards and their GraphQL API, DefaultJobsURL by default.
+func WithJobsURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.jobsURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Gem.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Gem, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Gem, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Gem given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+// ScrapeCompanyInfo scrapes company information for a given company from Gem.
+func (l Loader) ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return ScrapeCompanyInfo(l.withURLs(ctx), companyName)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.apiURL == "" {
+		l.apiURL = DefaultAPIURL
+	}
+
+	if l.jobsURL == "" {
+		l.jobsURL = DefaultJobsURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultAPIURL is the base URL of the public Gem job board API.
+	DefaultAPIURL = "https://api.gem.com"
+	// DefaultJobsURL is the base URL of the hosted Gem job boards.
+	DefaultJobsURL = "https://jobs.gem.com"
+
 	gemJobQuery = "[\n  {\n    \"operationName\": \"ExternalJobPostingQuery\",\n    \"variables\": {\n      \"boardId\": \"%s\",\n      \"extId\": \"%s\"\n    },\n    \"query\": \"fragment ExternalJobPostFragment on PublicOatsJobPost {\\n  id\\n  title\\n  descriptionHtml\\n  extId\\n  startDateTs\\n  firstPublishedTsSec\\n  companyLogo\\n  companyUrl\\n  isApplicationFormHidden\\n  applicationFormTemplate {\\n    id\\n    includeEeoc\\n    eeocConfig {\\n      includeRaceXGender\\n      includeVeteranStatus\\n      includeDisabilityStatus\\n      __typename\\n    }\\n    __typename\\n  }\\n  isUnlistedExternally\\n  locations {\\n    id\\n    name\\n    city\\n    isoCountry\\n    isRemote\\n    extId\\n    __typename\\n  }\\n  job {\\n    id\\n    locationType\\n    employmentType\\n    requisitionId\\n    teamDisplayName\\n    department {\\n      id\\n      name\\n      extId\\n      __typename\\n    }\\n    locations {\\n      id\\n      name\\n      city\\n      isoCountry\\n      isRemote\\n      extId\\n      __typename\\n    }\\n    __typename\\n  }\\n  jobPostSectionHtml {\\n    introHtml\\n    outroHtml\\n    __typename\\n  }\\n  __typename\\n}\\n\\nquery ExternalJobPostingQuery($boardId: String!, $extId: String!) {\\n  oatsExternalJobPosting(boardId: $boardId, extId: $extId) {\\n    id\\n    ...ExternalJobPostFragment\\n    __typename\\n  }\\n  oatsJobPostFieldsAndQuestions(\\n    jobBoardVanityPath: $boardId\\n    jobPostExtId: $extId\\n  ) {\\n    fields {\\n      fieldType\\n      isRequired\\n      __typename\\n    }\\n    questions {\\n      extId\\n      answerType\\n      displayType\\n      fileType\\n      text\\n      description\\n      isRequired\\n      options {\\n        extId\\n        value\\n        __typename\\n      }\\n      __typename\\n    }\\n    __typename\\n  }\\n}\\n\"\n  }\n]\n"
 
 	gemCompanyQuery = "{\"query\":\"query JobBoardTheme($boardId: String!) { \\n  publicBrandingTheme(externalId: $boardId) {\\n    id\\n    theme \\n    __typename\\n  }\\n}\",\"variables\":{\"boardId\": \"%s\"}}"
-	gemGql          = "https://jobs.gem.com/api/public/graphql/batch"
-	gemURL          = "https://api.gem.com/job_board/v0/%s/job_posts/"
+	gemGql          = "%s/api/public/graphql/batch"
+	gemURL          = "%s/job_board/v0/%s/job_posts/"
 )
 
 // ScrapeCompany scrapes all job postings for a given company from the Gem ATS.
 func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
-	slog.DebugContext(ctx, "Scraping company", slog.String("ats", "gem"), slog.String("company_name", companyName))
+	result, err := ScrapeCompanyResult(ctx, companyName)
 
-	jobs := make([]*models.Job, 0)
+	return result.Jobs, err
+}
 
-	body, err := helpers.GetJSON(
-		ctx,
-		fmt.Sprintf(gemURL, companyName),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error getting JSON from Gem job board endpoint: %w", err)
-	}
+// ScrapeCompanyResult scrapes all jobs for a given company from Gem ATS, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
+
+// StreamCompany scrapes all jobs for a given company from Gem ATS, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "gem"), slog.String("company_name", companyName))
+
+		ctx = companyinfo.Ensure(ctx)
 
-	_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
-		job, jerr := parseGemCompanyJob(ctx, value)
-		if jerr != nil {
-			slog.ErrorContext(ctx, "Error parsing Gem job from jobs array", slog.Any("error", jerr))
+		body, err := helpers.GetJSON(
+			ctx,
+			fmt.Sprintf(gemURL, endpoints(ctx).apiURL, companyName),
+			nil,
+		)
+		if err != nil {
+			yield(nil, fmt.Errorf("error getting JSON from Gem job board endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
 			return
 		}
 
-		if job.Company.Name == "" {
-			company, err := ScrapeCompanyInfo(ctx, companyName)
-			if err != nil {
-				slog.ErrorContext(ctx, "Error scraping company info for Gem job", slog.String("company_name", companyName), slog.Any("error", err))
-			} else {
-				job.Company = company
+		stopped := false
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			if stopped {
+				return
 			}
-		}
 
-		slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
-		jobs = append(jobs, job)
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "Error parsing jobs array from Gem job board endpoint", slog.Any("error", err))
-		return jobs, fmt.Errorf("error parsing jobs array: %w", err)
-	}
+			job, jerr := parseGemCompanyJob(ctx, value)
+			if jerr != nil {
+				slog.ErrorContext(ctx, "Error parsing Gem job from jobs array", slog.Any("error", jerr))
+				jobID, _, _, _ := jsonparser.Get(value, "id")
+				stopped = !yield(nil, &models.JobError{JobID: string(jobID), Stage: models.StageList, Err: jerr})
+
+				return
+			}
+
+			if job.Company.Name == "" {
+				company, err := companyInfo(ctx, companyName)
+				if err != nil {
+					slog.ErrorContext(ctx, "Error scraping company info for Gem job", slog.String("company_name", companyName), slog.Any("error", err))
+
+					if !yield(nil, &models.JobError{JobID: job.SourceID, Stage: models.StageCompanyInfo, Err: err}) {
+						stopped = true
+						return
+					}
+				} else {
+					job.Company = company
+				}
+			}
 
-	return jobs, nil
+			slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
+			stopped = !yield(job, nil)
+		})
+		if err != nil && !stopped {
+			slog.ErrorContext(ctx, "Error parsing jobs array from Gem job board endpoint", slog.Any("error", err))
+			yield(nil, fmt.Errorf("error parsing jobs array: %w", err))
+		}
+	}
 }
 
 // ScrapeJob scrapes a specific job posting by job ID for a given company from the Gem ATS.
 func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	payload := strings.NewReader(
 		fmt.Sprintf(gemJobQuery, companyName, jobID),
 	)
 
 	bodyText, err := helpers.PostJSON(
 		ctx,
-		gemGql,
+		fmt.Sprintf(gemGql, endpoints(ctx).jobsURL),
 		payload,
 		nil,
 	)
@@ -80,14 +205,20 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 		return nil, fmt.Errorf("error getting JSON from Gem job board endpoint: %w", err)
 	}
 
+	// Gem answers with a null posting rather than a 404 once a job is removed
+	_, dataType, _, _ := jsonparser.Get(bodyText, "[0]", "data", "oatsExternalJobPosting")
+	if dataType == jsonparser.Null {
+		return nil, fmt.Errorf("%w: %s/%s", models.ErrJobGone, companyName, jobID)
+	}
+
 	job, err := parseGemOatsJob(ctx, bodyText)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing Gem job object: %w", err)
 	}
 
-	job.URL = fmt.Sprintf("https://jobs.gem.com/%s/%s", companyName, jobID)
+	job.URL = fmt.Sprintf("%s/%s/%s", endpoints(ctx).jobsURL, companyName, jobID)
 
-	company, err := ScrapeCompanyInfo(ctx, companyName)
+	company, err := companyInfo(ctx, companyName)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error scraping company info for Gem job", slog.String("company_name", companyName), slog.Any("error", err))
 	}
@@ -97,13 +228,23 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 	return job, nil
 }
 
+// companyInfo returns the company information for companyName through the company info
+// cache carried by ctx, if any.
+func companyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return companyinfo.Lookup(ctx, Source, companyName, func(ctx context.Context) (*models.Company, error) {
+		return ScrapeCompanyInfo(ctx, companyName)
+	})
+}
+
 // ScrapeCompanyInfo scrapes company information for a given company from the Gem ATS.
 func ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	payload := strings.NewReader(fmt.Sprintf(gemCompanyQuery, companyName))
 
 	bodyText, err := helpers.PostJSON(
 		ctx,
-		gemGql,
+		fmt.Sprintf(gemGql, endpoints(ctx).jobsURL),
 		payload,
 		nil,
 	)
@@ -133,7 +274,7 @@ func ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company
 }
 
 func parseGemCompanyJob(ctx context.Context, data []byte) (*models.Job, error) {
-	job := models.NewJob("gem", data)
+	job := models.NewJob(Source, data)
 
 	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
 		switch string(key) {
@@ -208,7 +349,7 @@ func parseGemCompanyJob(ctx context.Context, data []byte) (*models.Job, error) {
 }
 
 func parseGemOatsJob(ctx context.Context, data []byte) (*models.Job, error) {
-	job := models.NewJob("gem", data)
+	job := models.NewJob(Source, data)
 
 	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
 		switch string(key) {
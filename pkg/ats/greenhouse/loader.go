@@ -4,76 +4,203 @@ package greenhouse
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"net/url"
 	"strconv"
+	"strings"
 
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
 	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
 	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
 	"github.com/buger/jsonparser"
 )
 
-var (
-	greenhouseCompanyURL     = "https://boards-api.greenhouse.io/v1/boards/%s/jobs?content=true&pay_transparency=true"
-	greenhouseCompanyInfoURL = "https://job-boards.greenhouse.io/%s?_data=root"
-	greenhouseJobURL         = "https://boards-api.greenhouse.io/v1/boards/%s/jobs/%s?content=true&pay_transparency=true"
+// Source identifies jobs scraped from Greenhouse and is the name the loader is registered under.
+const Source = "greenhouse"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Greenhouse endpoints; use New to point it elsewhere.
+type Loader struct {
+	boardsAPIURL string
+	jobBoardsURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithBoardsAPIURL sets the base URL of the job board API, DefaultBoardsAPIURL by default.
+func WithBoardsAPIURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.boardsAPIURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithJobBoardsURL sets the base URL of the hosted job boards, DefaultJobBoardsURL by default.
+func WithJobBoardsURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.jobBoardsURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Greenhouse.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Greenhouse, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Greenhouse, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Greenhouse given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+// ScrapeCompanyInfo scrapes company information for a given company from Greenhouse.
+func (l Loader) ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return ScrapeCompanyInfo(l.withURLs(ctx), companyName)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.boardsAPIURL == "" {
+		l.boardsAPIURL = DefaultBoardsAPIURL
+	}
+
+	if l.jobBoardsURL == "" {
+		l.jobBoardsURL = DefaultJobBoardsURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultBoardsAPIURL is the base URL of the public Greenhouse job board API.
+	DefaultBoardsAPIURL = "https://boards-api.greenhouse.io"
+	// DefaultJobBoardsURL is the base URL of the hosted Greenhouse job boards.
+	DefaultJobBoardsURL = "https://job-boards.greenhouse.io"
+
+	greenhouseCompanyURL     = "%s/v1/boards/%s/jobs?content=true&pay_transparency=true"
+	greenhouseCompanyInfoURL = "%s/%s?_data=root"
+	greenhouseJobURL         = "%s/v1/boards/%s/jobs/%s?content=true&pay_transparency=true"
 )
 
 // ScrapeCompany scrapes all jobs for a given company from Greenhouse ATS.
 func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
-	slog.DebugContext(ctx, "Scraping company", slog.String("ats", "greenhouse"), slog.String("company_name", companyName))
+	result, err := ScrapeCompanyResult(ctx, companyName)
 
-	jobs := make([]*models.Job, 0)
+	return result.Jobs, err
+}
 
-	// The URL is like https://boards-api.greenhouse.io/v1/boards/{companyName}/jobs?content=true
-	companyURL := fmt.Sprintf(greenhouseCompanyURL, companyName)
+// ScrapeCompanyResult scrapes all jobs for a given company from Greenhouse ATS, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
 
-	// Get the JSON from the company job board endpoint
-	body, err := helpers.GetJSON(ctx, companyURL, nil)
-	if err != nil {
-		slog.ErrorContext(ctx, "Error getting JSON from Greenhouse job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
-		return jobs, fmt.Errorf("error getting JSON from Greenhouse job board endpoint: %w", err)
-	}
+// StreamCompany scrapes all jobs for a given company from Greenhouse ATS, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "greenhouse"), slog.String("company_name", companyName))
+
+		ctx = companyinfo.Ensure(ctx)
+
+		// The URL is like https://boards-api.greenhouse.io/v1/boards/{companyName}/jobs?content=true
+		companyURL := fmt.Sprintf(greenhouseCompanyURL, endpoints(ctx).boardsAPIURL, companyName)
+
+		// Get the JSON from the company job board endpoint
+		body, err := helpers.GetJSON(ctx, companyURL, nil)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error getting JSON from Greenhouse job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
+			yield(nil, fmt.Errorf("error getting JSON from Greenhouse job board endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
 
-	_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
-		job, jerr := parseGreenhouseJob(ctx, value)
-		if jerr != nil {
-			slog.ErrorContext(ctx, "Error parsing Greenhouse job from jobs array", slog.Any("error", jerr))
 			return
 		}
 
-		if job.Company.Name == "" {
-			company, err := ScrapeCompanyInfo(ctx, companyName)
-			if err != nil {
-				slog.ErrorContext(ctx, "Error scraping company info for Greenhouse job", slog.String("company_name", companyName), slog.Any("error", err))
-			} else {
-				job.Company = company
+		stopped := false
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			if stopped {
+				return
 			}
-		}
 
-		slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
-		jobs = append(jobs, job)
-	}, "jobs")
-	if err != nil {
-		slog.ErrorContext(ctx, "Error parsing jobs array from Greenhouse job board endpoint", slog.Any("error", err))
-		return jobs, fmt.Errorf("error parsing jobs array: %w", err)
-	}
+			job, jerr := parseGreenhouseJob(ctx, value)
+			if jerr != nil {
+				slog.ErrorContext(ctx, "Error parsing Greenhouse job from jobs array", slog.Any("error", jerr))
+				jobID, _, _, _ := jsonparser.Get(value, "id")
+				stopped = !yield(nil, &models.JobError{JobID: string(jobID), Stage: models.StageList, Err: jerr})
+
+				return
+			}
+
+			if job.Company.Name == "" {
+				company, err := companyInfo(ctx, companyName)
+				if err != nil {
+					slog.ErrorContext(ctx, "Error scraping company info for Greenhouse job", slog.String("company_name", companyName), slog.Any("error", err))
+
+					if !yield(nil, &models.JobError{JobID: job.SourceID, Stage: models.StageCompanyInfo, Err: err}) {
+						stopped = true
+						return
+					}
+				} else {
+					job.Company = company
+				}
+			}
 
-	return jobs, nil
+			slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
+			stopped = !yield(job, nil)
+		}, "jobs")
+		if err != nil && !stopped {
+			slog.ErrorContext(ctx, "Error parsing jobs array from Greenhouse job board endpoint", slog.Any("error", err))
+			yield(nil, fmt.Errorf("error parsing jobs array: %w", err))
+		}
+	}
 }
 
 // ScrapeJob scrapes an individual job from Greenhouse ATS given the company name and job ID.
 func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "greenhouse"), slog.String("company_name", companyName), slog.String("job_id", jobID))
 
 	// The URL is like https://boards-api.greenhouse.io/v1/boards/{companyName}/jobs/{jobID}?content=true
-	jobURL := fmt.Sprintf(greenhouseJobURL, companyName, jobID)
+	jobURL := fmt.Sprintf(greenhouseJobURL, endpoints(ctx).boardsAPIURL, companyName, jobID)
 
 	// Get the JSON from the job endpoint
 	body, err := helpers.GetJSON(ctx, jobURL, nil)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error getting JSON from Greenhouse job endpoint", slog.String("url", jobURL), slog.Any("error", err))
-		return nil, fmt.Errorf("error getting JSON from Greenhouse job endpoint: %w", err)
+		return nil, fmt.Errorf("error getting JSON from Greenhouse job endpoint: %w", helpers.WrapNotFound(err, models.ErrJobGone))
 	}
 
 	job, err := parseGreenhouseJob(ctx, body)
@@ -82,7 +209,7 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 		return nil, fmt.Errorf("error parsing Greenhouse job from job endpoint: %w", err)
 	}
 
-	company, err := ScrapeCompanyInfo(ctx, companyName)
+	company, err := companyInfo(ctx, companyName)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error scraping company info for Greenhouse job", slog.String("company_name", companyName), slog.Any("error", err))
 	}
@@ -94,15 +221,25 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 	return job, nil
 }
 
+// companyInfo returns the company information for companyName through the company info
+// cache carried by ctx, if any.
+func companyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return companyinfo.Lookup(ctx, Source, companyName, func(ctx context.Context) (*models.Company, error) {
+		return ScrapeCompanyInfo(ctx, companyName)
+	})
+}
+
 // ScrapeCompanyInfo scrapes company information for a given company from the Greenhouse ATS.
 func ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	bodyText, err := helpers.GetJSON(
 		ctx,
-		fmt.Sprintf(greenhouseCompanyInfoURL, companyName),
+		fmt.Sprintf(greenhouseCompanyInfoURL, endpoints(ctx).jobBoardsURL, companyName),
 		nil,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error getting JSON from Greenhouse company info endpoint: %w", err)
+		return nil, fmt.Errorf("error getting JSON from Greenhouse company info endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound))
 	}
 
 	company := models.NewCompany()
@@ -138,7 +275,7 @@ func ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company
 }
 
 func parseGreenhouseJob(ctx context.Context, data []byte) (*models.Job, error) {
-	job := models.NewJob("greenhouse", data)
+	job := models.NewJob(Source, data)
 
 	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
 		switch string(key) {
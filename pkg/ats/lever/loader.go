@@ -4,72 +4,185 @@ package lever
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"net/url"
+	"strings"
 
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
 	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
 	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
 	"github.com/buger/jsonparser"
 )
 
-var (
-	leverCompanyURL = "https://api.lever.co/v0/postings/%s?mode=json"
-	leverJobURL     = "https://api.lever.co/v0/postings/%s/%s?mode=json"
+// Source identifies jobs scraped from Lever and is the name the loader is registered under.
+const Source = "lever"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Lever endpoints; use New to point it elsewhere.
+type Loader struct {
+	apiURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithAPIURL sets the base URL of the postings API, DefaultAPIURL by default. Accounts
+// hosted in the EU are served from EUAPIURL.
+func WithAPIURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.apiURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Lever.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Lever, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Lever, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Lever given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.apiURL == "" {
+		l.apiURL = DefaultAPIURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultAPIURL is the base URL of the Lever postings API.
+	DefaultAPIURL = "https://api.lever.co"
+	// EUAPIURL is the base URL of the Lever postings API for accounts hosted in the EU.
+	EUAPIURL = "https://api.eu.lever.co"
+
+	leverCompanyURL = "%s/v0/postings/%s?mode=json"
+	leverJobURL     = "%s/v0/postings/%s/%s?mode=json"
 )
 
 // ScrapeCompany scrapes all jobs for a given company from Lever ATS.
 func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
-	slog.DebugContext(ctx, "Scraping company", slog.String("ats", "lever"), slog.String("company_name", companyName))
+	result, err := ScrapeCompanyResult(ctx, companyName)
 
-	jobs := make([]*models.Job, 0)
+	return result.Jobs, err
+}
 
-	// The URL is like https://api.lever.co/v0/postings/{companyName}?mode=json
-	companyURL := fmt.Sprintf(leverCompanyURL, companyName)
+// ScrapeCompanyResult scrapes all jobs for a given company from Lever ATS, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
 
-	// Get the JSON from the company job board endpoint
-	body, err := helpers.GetJSON(ctx, companyURL, nil)
-	if err != nil {
-		slog.ErrorContext(ctx, "Error getting JSON from Lever job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
-		return jobs, fmt.Errorf("error getting JSON from Lever job board endpoint: %w", err)
-	}
+// StreamCompany scrapes all jobs for a given company from Lever ATS, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "lever"), slog.String("company_name", companyName))
+
+		ctx = companyinfo.Ensure(ctx)
+
+		// The URL is like https://api.lever.co/v0/postings/{companyName}?mode=json
+		companyURL := fmt.Sprintf(leverCompanyURL, endpoints(ctx).apiURL, companyName)
+
+		// Get the JSON from the company job board endpoint
+		body, err := helpers.GetJSON(ctx, companyURL, nil)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error getting JSON from Lever job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
+			yield(nil, fmt.Errorf("error getting JSON from Lever job board endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
 
-	_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
-		job, jerr := parseLeverJob(ctx, value)
-		if jerr != nil {
-			slog.ErrorContext(ctx, "Error parsing Lever job from jobs array", slog.Any("error", jerr))
 			return
 		}
 
-		if job.Company.Name == "" {
-			err := scrapeCompanyInfo(ctx, job)
-			if err != nil {
-				slog.ErrorContext(ctx, "Error scraping company info from job URL", slog.String("url", job.URL), slog.Any("error", err))
-				// we continue even if there's an error here
+		stopped := false
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			if stopped {
+				return
 			}
-		}
 
-		jobs = append(jobs, job)
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "Error parsing jobs array from Lever job board endpoint", slog.Any("error", err))
-		return jobs, fmt.Errorf("error parsing jobs array: %w", err)
-	}
+			job, jerr := parseLeverJob(ctx, value)
+			if jerr != nil {
+				slog.ErrorContext(ctx, "Error parsing Lever job from jobs array", slog.Any("error", jerr))
+				jobID, _, _, _ := jsonparser.Get(value, "id")
+				stopped = !yield(nil, &models.JobError{JobID: string(jobID), Stage: models.StageList, Err: jerr})
+
+				return
+			}
 
-	return jobs, nil
+			if job.Company.Name == "" {
+				company, err := companyInfo(ctx, companyName, job.URL)
+				if err != nil {
+					slog.ErrorContext(ctx, "Error scraping company info from job URL", slog.String("url", job.URL), slog.Any("error", err))
+
+					// we continue even if there's an error here
+					if !yield(nil, &models.JobError{JobID: job.SourceID, Stage: models.StageCompanyInfo, Err: err}) {
+						stopped = true
+						return
+					}
+				} else {
+					job.Company = company
+				}
+			}
+
+			stopped = !yield(job, nil)
+		})
+		if err != nil && !stopped {
+			slog.ErrorContext(ctx, "Error parsing jobs array from Lever job board endpoint", slog.Any("error", err))
+			yield(nil, fmt.Errorf("error parsing jobs array: %w", err))
+		}
+	}
 }
 
 // ScrapeJob scrapes an individual job from Lever ATS given the company name and job ID.
 func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "lever"), slog.String("company_name", companyName), slog.String("job_id", jobID))
 
 	// The URL is like https://api.lever.co/v0/postings/{companyName}/{jobID}?mode=json
-	jobURL := fmt.Sprintf(leverJobURL, companyName, jobID)
+	jobURL := fmt.Sprintf(leverJobURL, endpoints(ctx).apiURL, companyName, jobID)
 
 	// Get the JSON from the job endpoint
 	body, err := helpers.GetJSON(ctx, jobURL, nil)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error getting JSON from Lever job endpoint", slog.String("url", jobURL), slog.Any("error", err))
-		return nil, fmt.Errorf("error getting JSON from Lever job endpoint: %w", err)
+		return nil, fmt.Errorf("error getting JSON from Lever job endpoint: %w", helpers.WrapNotFound(err, models.ErrJobGone))
 	}
 
 	job, err := parseLeverJob(ctx, body)
@@ -78,10 +191,12 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 		return nil, fmt.Errorf("error parsing Lever job from job endpoint: %w", err)
 	}
 	// Try to get company info from LD+JSON on the job page
-	err = scrapeCompanyInfo(ctx, job)
+	company, err := companyInfo(ctx, companyName, job.URL)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error scraping company info from job URL", slog.String("url", job.URL), slog.Any("error", err))
 		// we continue even if there's an error here
+	} else {
+		job.Company = company
 	}
 
 	slog.DebugContext(ctx, "Parsed job", slog.String("job_id", job.SourceID), slog.String("title", job.Title))
@@ -89,19 +204,35 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 	return job, nil
 }
 
-func scrapeCompanyInfo(ctx context.Context, job *models.Job) error {
+// companyInfo returns the company information for companyName through the company info
+// cache carried by ctx, if any. Lever has no company endpoint, so a cache miss reads the
+// LD+JSON of the given job page. A failure is forgotten so the next job tries its own page.
+func companyInfo(ctx context.Context, companyName, jobURL string) (*models.Company, error) {
+	company, err := companyinfo.Lookup(ctx, Source, companyName, func(ctx context.Context) (*models.Company, error) {
+		return scrapeCompanyInfo(ctx, jobURL)
+	})
+	if err != nil {
+		companyinfo.Forget(ctx, Source, companyName)
+	}
+
+	return company, err //nolint:wrapcheck // scrapeCompanyInfo already wraps its errors
+}
+
+func scrapeCompanyInfo(ctx context.Context, jobURL string) (*models.Company, error) {
 	// Try to get company name and logo from LD+JSON on the job page
-	json, err := helpers.GetLDJSON(ctx, job.URL)
+	json, err := helpers.GetLDJSON(ctx, jobURL)
 	if err != nil {
-		slog.ErrorContext(ctx, "Error getting LD+JSON from job URL", slog.String("url", job.URL), slog.Any("error", err))
-		return fmt.Errorf("error getting LD+JSON from job URL: %w", err)
+		slog.ErrorContext(ctx, "Error getting LD+JSON from job URL", slog.String("url", jobURL), slog.Any("error", err))
+		return nil, fmt.Errorf("error getting LD+JSON from job URL: %w", err)
 	}
 
+	company := models.NewCompany()
+
 	org, ok := json["hiringOrganization"].(map[string]any)
 	if ok {
 		name, ok := org["name"].(string)
 		if ok {
-			job.Company.Name = name
+			company.Name = name
 		}
 	}
 
@@ -109,15 +240,15 @@ func scrapeCompanyInfo(ctx context.Context, job *models.Job) error {
 	if ok {
 		logoURL, err := url.Parse(logo)
 		if err == nil {
-			job.Company.Logo = *logoURL
+			company.Logo = *logoURL
 		}
 	}
 
-	return nil
+	return company, nil
 }
 
 func parseLeverJob(ctx context.Context, data []byte) (*models.Job, error) {
-	job := models.NewJob("lever", data)
+	job := models.NewJob(Source, data)
 
 	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
 		switch string(key) {
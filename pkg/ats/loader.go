@@ -0,0 +1,324 @@
+// Package ats ties the individual ATS loader packages together behind a common interface and registry.
+package ats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/ashby"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/bamboo"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/gem"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/greenhouse"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/lever"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/personio"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/recruitee"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/rippling"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/smartrecruiters"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/teamtailor"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/workable"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/workday"
+)
+
+var (
+	// ErrUnknownATS is returned when no loader is registered under the requested name.
+	ErrUnknownATS = errors.New("unknown ATS")
+	// ErrDuplicateLoader is returned when a loader is already registered under the given name.
+	ErrDuplicateLoader = errors.New("loader already registered")
+	// ErrInvalidLoader is returned when registering a loader with an empty name or a nil loader.
+	ErrInvalidLoader = errors.New("invalid loader")
+	// ErrCompanyInfoUnsupported is returned when a loader cannot scrape company information.
+	ErrCompanyInfoUnsupported = errors.New("loader does not support scraping company info")
+	// ErrAPIKeyUnsupported is returned when an API key is given for an ATS that doesn't take one.
+	ErrAPIKeyUnsupported = errors.New("ATS does not take an API key")
+
+	defaultRegistry = newDefaultRegistry()
+)
+
+// Loader is implemented by every ATS package that can scrape jobs.
+type Loader interface {
+	// ScrapeCompany scrapes all jobs for a given company.
+	ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error)
+	// ScrapeJob scrapes an individual job given the company name and job ID.
+	ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error)
+}
+
+// CompanyInfoLoader is optionally implemented by loaders that can scrape company information on its own.
+type CompanyInfoLoader interface {
+	// ScrapeCompanyInfo scrapes company information for a given company.
+	ScrapeCompanyInfo(ctx context.Context, companyName string) (*models.Company, error)
+}
+
+// ResultLoader is optionally implemented by loaders that report the jobs they failed to
+// scrape instead of only logging them.
+type ResultLoader interface {
+	// ScrapeCompanyResult scrapes all jobs for a given company, including the jobs that failed.
+	ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error)
+}
+
+// StreamLoader is optionally implemented by loaders that can yield jobs while the rest of
+// the company is still being scraped.
+type StreamLoader interface {
+	// StreamCompany scrapes all jobs for a given company, yielding each one as it arrives.
+	// Failed jobs are yielded as a *models.JobError; any other error ends the stream.
+	StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error]
+}
+
+// Compile-time checks that the built-in loaders satisfy the interfaces.
+var (
+	_ Loader            = ashby.Loader{}
+	_ Loader            = bamboo.Loader{}
+	_ Loader            = gem.Loader{}
+	_ Loader            = greenhouse.Loader{}
+	_ Loader            = lever.Loader{}
+	_ Loader            = personio.Loader{}
+	_ Loader            = recruitee.Loader{}
+	_ Loader            = rippling.Loader{}
+	_ Loader            = smartrecruiters.Loader{}
+	_ Loader            = teamtailor.Loader{}
+	_ Loader            = workable.Loader{}
+	_ Loader            = workday.Loader{}
+	_ CompanyInfoLoader = ashby.Loader{}
+	_ CompanyInfoLoader = bamboo.Loader{}
+	_ CompanyInfoLoader = gem.Loader{}
+	_ CompanyInfoLoader = greenhouse.Loader{}
+	_ ResultLoader      = ashby.Loader{}
+	_ ResultLoader      = bamboo.Loader{}
+	_ ResultLoader      = gem.Loader{}
+	_ ResultLoader      = greenhouse.Loader{}
+	_ ResultLoader      = lever.Loader{}
+	_ ResultLoader      = personio.Loader{}
+	_ ResultLoader      = recruitee.Loader{}
+	_ ResultLoader      = rippling.Loader{}
+	_ ResultLoader      = smartrecruiters.Loader{}
+	_ ResultLoader      = teamtailor.Loader{}
+	_ ResultLoader      = workable.Loader{}
+	_ ResultLoader      = workday.Loader{}
+	_ StreamLoader      = ashby.Loader{}
+	_ StreamLoader      = bamboo.Loader{}
+	_ StreamLoader      = gem.Loader{}
+	_ StreamLoader      = greenhouse.Loader{}
+	_ StreamLoader      = lever.Loader{}
+	_ StreamLoader      = personio.Loader{}
+	_ StreamLoader      = recruitee.Loader{}
+	_ StreamLoader      = rippling.Loader{}
+	_ StreamLoader      = smartrecruiters.Loader{}
+	_ StreamLoader      = teamtailor.Loader{}
+	_ StreamLoader      = workable.Loader{}
+	_ StreamLoader      = workday.Loader{}
+)
+
+// newDefaultRegistry returns a Registry holding the built-in loaders.
+func newDefaultRegistry() *Registry {
+	registry := NewRegistry()
+
+	for name, loader := range map[string]Loader{
+		ashby.Source:           ashby.Loader{},
+		bamboo.Source:          bamboo.Loader{},
+		gem.Source:             gem.Loader{},
+		greenhouse.Source:      greenhouse.Loader{},
+		lever.Source:           lever.Loader{},
+		personio.Source:        personio.Loader{},
+		recruitee.Source:       recruitee.Loader{},
+		rippling.Source:        rippling.Loader{},
+		smartrecruiters.Source: smartrecruiters.Loader{},
+		teamtailor.Source:      teamtailor.Loader{},
+		workable.Source:        workable.Loader{},
+		workday.Source:         workday.Loader{},
+	} {
+		err := registry.Register(name, loader)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return registry
+}
+
+// KeyedLoader returns a loader for the named ATS that scrapes companyName with the company's
+// API key. Teamtailor is the only built-in ATS that takes one.
+func KeyedLoader(name, companyName, key string) (Loader, error) {
+	switch normalizeName(name) {
+	case teamtailor.Source:
+		return teamtailor.New(teamtailor.WithAPIKey(companyName, key)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrAPIKeyUnsupported, name)
+	}
+}
+
+// Registry maps ATS names to their loaders. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	loaders map[string]Loader
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		loaders: make(map[string]Loader),
+	}
+}
+
+// Register adds a loader under the given name. Names are case-insensitive.
+func (r *Registry) Register(name string, loader Loader) error {
+	key := normalizeName(name)
+	if key == "" || loader == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidLoader, name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.loaders[key]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateLoader, name)
+	}
+
+	r.loaders[key] = loader
+
+	return nil
+}
+
+// Get returns the loader registered under the given name.
+func (r *Registry) Get(name string) (Loader, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	loader, ok := r.loaders[normalizeName(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownATS, name)
+	}
+
+	return loader, nil
+}
+
+// Names returns the sorted names of all registered loaders.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.loaders))
+	for name := range r.loaders {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// ScrapeCompanyInfo scrapes company information using the loader registered under the given name.
+func (r *Registry) ScrapeCompanyInfo(ctx context.Context, name, companyName string) (*models.Company, error) {
+	loader, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	infoLoader, ok := loader.(CompanyInfoLoader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrCompanyInfoUnsupported, name)
+	}
+
+	return infoLoader.ScrapeCompanyInfo(ctx, companyName) //nolint:wrapcheck // loaders already wrap their errors
+}
+
+// ScrapeCompanyResult scrapes all jobs for a company using the loader registered under the
+// given name. Loaders that don't implement ResultLoader report no per-job errors.
+func (r *Registry) ScrapeCompanyResult(ctx context.Context, name, companyName string) (*models.ScrapeResult, error) {
+	loader, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return scrapeCompanyResult(ctx, loader, companyName)
+}
+
+func scrapeCompanyResult(ctx context.Context, loader Loader, companyName string) (*models.ScrapeResult, error) {
+	resultLoader, ok := loader.(ResultLoader)
+	if ok {
+		return resultLoader.ScrapeCompanyResult(ctx, companyName) //nolint:wrapcheck // loaders already wrap their errors
+	}
+
+	result := models.NewScrapeResult()
+
+	jobs, err := loader.ScrapeCompany(ctx, companyName)
+	if jobs != nil {
+		result.Jobs = jobs
+	}
+
+	return result, err //nolint:wrapcheck // loaders already wrap their errors
+}
+
+// StreamCompany returns a stream of all jobs for a company using the loader registered
+// under the given name. Loaders that don't implement StreamLoader yield their jobs once
+// ScrapeCompany returns.
+func (r *Registry) StreamCompany(ctx context.Context, name, companyName string) (iter.Seq2[*models.Job, error], error) {
+	loader, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return Stream(ctx, loader, companyName), nil
+}
+
+// Stream returns a stream of all jobs for a company using the given loader, for loaders
+// that aren't in a registry. Loaders that don't implement StreamLoader yield their jobs
+// once ScrapeCompany returns.
+func Stream(ctx context.Context, loader Loader, companyName string) iter.Seq2[*models.Job, error] {
+	streamLoader, ok := loader.(StreamLoader)
+	if ok {
+		return streamLoader.StreamCompany(ctx, companyName)
+	}
+
+	return func(yield func(*models.Job, error) bool) {
+		jobs, err := loader.ScrapeCompany(ctx, companyName)
+
+		for _, job := range jobs {
+			if !yield(job, nil) {
+				return
+			}
+		}
+
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// Register adds a loader to the default registry.
+func Register(name string, loader Loader) error {
+	return defaultRegistry.Register(name, loader)
+}
+
+// Get returns the loader registered under the given name in the default registry.
+func Get(name string) (Loader, error) {
+	return defaultRegistry.Get(name)
+}
+
+// Names returns the sorted names of all loaders in the default registry.
+func Names() []string {
+	return defaultRegistry.Names()
+}
+
+// ScrapeCompanyInfo scrapes company information using the named loader from the default registry.
+func ScrapeCompanyInfo(ctx context.Context, name, companyName string) (*models.Company, error) {
+	return defaultRegistry.ScrapeCompanyInfo(ctx, name, companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a company using the named loader from the default registry.
+func ScrapeCompanyResult(ctx context.Context, name, companyName string) (*models.ScrapeResult, error) {
+	return defaultRegistry.ScrapeCompanyResult(ctx, name, companyName)
+}
+
+// StreamCompany returns a stream of all jobs for a company using the named loader from the default registry.
+func StreamCompany(ctx context.Context, name, companyName string) (iter.Seq2[*models.Job, error], error) {
+	return defaultRegistry.StreamCompany(ctx, name, companyName)
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,156 @@
+package ats
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/teamtailor"
+)
+
+type fakeLoader struct{}
+
+func (fakeLoader) ScrapeCompany(_ context.Context, _ string) ([]*models.Job, error) {
+	return []*models.Job{models.NewJob("fake", nil)}, nil
+}
+
+func (fakeLoader) ScrapeJob(_ context.Context, _, jobID string) (*models.Job, error) {
+	job := models.NewJob("fake", nil)
+	job.SourceID = jobID
+
+	return job, nil
+}
+
+func TestDefaultRegistryNames(t *testing.T) {
+	t.Parallel()
+
+	want := []string{"ashby", "bamboo", "gem", "greenhouse", "lever", "personio", "recruitee", "rippling", "smartrecruiters", "teamtailor", "workable", "workday"}
+	if got := Names(); !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Get("Greenhouse"); err != nil {
+		t.Errorf("Get(Greenhouse) error = %v", err)
+	}
+
+	if _, err := Get("nope"); !errors.Is(err, ErrUnknownATS) {
+		t.Errorf("Get(nope) error = %v, want %v", err, ErrUnknownATS)
+	}
+}
+
+func TestKeyedLoader(t *testing.T) {
+	t.Parallel()
+
+	loader, err := KeyedLoader("Teamtailor", "acme", "public-key")
+	if err != nil {
+		t.Fatalf("KeyedLoader(Teamtailor) error = %v", err)
+	}
+
+	if loader != teamtailor.New(teamtailor.WithAPIKey("acme", "public-key")) {
+		t.Errorf("KeyedLoader(Teamtailor) = %+v, want a loader with the key for acme", loader)
+	}
+
+	if _, err := KeyedLoader("greenhouse", "acme", "public-key"); !errors.Is(err, ErrAPIKeyUnsupported) {
+		t.Errorf("KeyedLoader(greenhouse) error = %v, want %v", err, ErrAPIKeyUnsupported)
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+
+	if err := registry.Register("fake", fakeLoader{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	if err := registry.Register("FAKE", fakeLoader{}); !errors.Is(err, ErrDuplicateLoader) {
+		t.Errorf("Register() duplicate error = %v, want %v", err, ErrDuplicateLoader)
+	}
+
+	if err := registry.Register("", fakeLoader{}); !errors.Is(err, ErrInvalidLoader) {
+		t.Errorf("Register() empty name error = %v, want %v", err, ErrInvalidLoader)
+	}
+
+	loader, err := registry.Get("fake")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	job, err := loader.ScrapeJob(t.Context(), "acme", "123")
+	if err != nil {
+		t.Fatalf("ScrapeJob() error = %v", err)
+	}
+
+	if job.SourceID != "123" {
+		t.Errorf("ScrapeJob() SourceID = %v, want %v", job.SourceID, "123")
+	}
+
+	if _, err := registry.ScrapeCompanyInfo(t.Context(), "fake", "acme"); !errors.Is(err, ErrCompanyInfoUnsupported) {
+		t.Errorf("ScrapeCompanyInfo() error = %v, want %v", err, ErrCompanyInfoUnsupported)
+	}
+}
+
+func TestRegistryScrapeCompanyResult(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	if err := registry.Register("fake", fakeLoader{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	result, err := registry.ScrapeCompanyResult(t.Context(), "fake", "acme")
+	if err != nil {
+		t.Fatalf("ScrapeCompanyResult() error = %v", err)
+	}
+
+	if len(result.Jobs) != 1 || len(result.Errors) != 0 {
+		t.Errorf("ScrapeCompanyResult() = %d jobs, %d errors, want 1 job, 0 errors", len(result.Jobs), len(result.Errors))
+	}
+
+	if _, err := registry.ScrapeCompanyResult(t.Context(), "nope", "acme"); !errors.Is(err, ErrUnknownATS) {
+		t.Errorf("ScrapeCompanyResult() error = %v, want %v", err, ErrUnknownATS)
+	}
+}
+
+func TestRegistryStreamCompany(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	if err := registry.Register("fake", fakeLoader{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	jobs, err := registry.StreamCompany(t.Context(), "fake", "acme")
+	if err != nil {
+		t.Fatalf("StreamCompany() error = %v", err)
+	}
+
+	count := 0
+
+	for job, err := range jobs {
+		if err != nil {
+			t.Fatalf("StreamCompany() yielded error %v", err)
+		}
+
+		if job.Source != "fake" {
+			t.Errorf("StreamCompany() job source = %v, want fake", job.Source)
+		}
+
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("StreamCompany() yielded %d jobs, want 1", count)
+	}
+
+	if _, err := registry.StreamCompany(t.Context(), "nope", "acme"); !errors.Is(err, ErrUnknownATS) {
+		t.Errorf("StreamCompany() error = %v, want %v", err, ErrUnknownATS)
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "strconv"
+
+// FieldChange describes a single field that differs between two versions of a job.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Diff returns the material differences between two versions of the same job posting.
+// Only fields that matter to someone tracking a posting are compared; metadata tags and
+// raw source data are ignored.
+func Diff(previous, current *Job) []FieldChange {
+	if previous == nil || current == nil {
+		return nil
+	}
+
+	changes := make([]FieldChange, 0)
+
+	compare := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	compare("title", previous.Title, current.Title)
+	compare("department", previous.DepartmentRaw, current.DepartmentRaw)
+	compare("employment_type", previous.EmploymentType.String(), current.EmploymentType.String())
+	compare("location", previous.Location, current.Location)
+	compare("location_type", previous.LocationType.String(), current.LocationType.String())
+	compare("is_remote", strconv.FormatBool(previous.IsRemote), strconv.FormatBool(current.IsRemote))
+	compare("compensation_unit", previous.CompensationUnit, current.CompensationUnit)
+	compare("min_compensation", formatFloat(previous.MinCompensation), formatFloat(current.MinCompensation))
+	compare("max_compensation", formatFloat(previous.MaxCompensation), formatFloat(current.MaxCompensation))
+	compare("description", previous.Description, current.Description)
+
+	return changes
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
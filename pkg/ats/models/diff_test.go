@@ -0,0 +1,46 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	previous := NewJob("ashby", nil)
+	previous.Title = "Engineer"
+	previous.MinCompensation = 100000
+	previous.MaxCompensation = 150000
+	previous.LocationType = OnsiteLocation
+	previous.Description = "<p>Build things</p>"
+	previous.AddMetadata("team", "Platform")
+
+	current := NewJob("ashby", nil)
+	current.Title = "Engineer"
+	current.MinCompensation = 110000
+	current.MaxCompensation = 150000
+	current.LocationType = RemoteLocation
+	current.Description = "<p>Build better things</p>"
+	current.AddMetadata("team", "Infrastructure")
+
+	changes := Diff(previous, current)
+
+	fields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+
+	want := []string{"location_type", "min_compensation", "description"}
+	if !slices.Equal(fields, want) {
+		t.Fatalf("Diff() fields = %v, want %v", fields, want)
+	}
+
+	if changes[1].Old != "100000" || changes[1].New != "110000" {
+		t.Errorf("Diff() min_compensation = %v -> %v, want 100000 -> 110000", changes[1].Old, changes[1].New)
+	}
+
+	if len(Diff(previous, previous)) != 0 {
+		t.Errorf("Diff() of identical jobs = %v, want none", Diff(previous, previous))
+	}
+}
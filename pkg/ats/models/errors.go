@@ -5,4 +5,8 @@ import "errors"
 var (
 	// ErrUnableToParseCompensation is returned when a compensation string cannot be parsed.
 	ErrUnableToParseCompensation = errors.New("unable to parse compensation string")
+	// ErrBoardNotFound is returned when a company has no job board on the ATS.
+	ErrBoardNotFound = errors.New("job board not found")
+	// ErrJobGone is returned when a job posting no longer exists, e.g. because it was filled or removed.
+	ErrJobGone = errors.New("job posting no longer exists")
 )
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ScrapeStage names the step of a company scrape at which a job failed.
+type ScrapeStage string
+
+const (
+	// StageList means the job could not be read from the company's job list.
+	StageList ScrapeStage = "list"
+	// StageDetail means the job's own posting could not be fetched or parsed.
+	StageDetail ScrapeStage = "detail"
+	// StageCompanyInfo means the company information for the job could not be fetched.
+	// The job itself is still part of the result.
+	StageCompanyInfo ScrapeStage = "company-info"
+)
+
+// JobError describes a job that failed at some stage of a company scrape.
+type JobError struct {
+	// JobID is the ATS's ID for the job. It is empty if the failure happened before the ID was known.
+	JobID string      `json:"job_id,omitempty"`
+	Stage ScrapeStage `json:"stage"`
+	Err   error       `json:"-"`
+}
+
+func (e *JobError) Error() string {
+	if e.JobID == "" {
+		return fmt.Sprintf("%s: %v", e.Stage, e.Err)
+	}
+
+	return fmt.Sprintf("job %s: %s: %v", e.JobID, e.Stage, e.Err)
+}
+
+func (e *JobError) Unwrap() error {
+	return e.Err
+}
+
+// Dropped reports whether the job is missing from the scrape because of the failure, as
+// opposed to only lacking company information.
+func (e *JobError) Dropped() bool {
+	return e.Stage != StageCompanyInfo
+}
+
+// MarshalJSON includes the error message, which encoding/json would otherwise drop.
+func (e *JobError) MarshalJSON() ([]byte, error) {
+	//nolint:wrapcheck // nothing to add
+	return json.Marshal(struct {
+		JobID string      `json:"job_id,omitempty"`
+		Stage ScrapeStage `json:"stage"`
+		Error string      `json:"error"`
+	}{
+		JobID: e.JobID,
+		Stage: e.Stage,
+		Error: e.Err.Error(),
+	})
+}
+
+// ScrapeResult holds the jobs of a company scrape along with the jobs that failed.
+type ScrapeResult struct {
+	Jobs   []*Job      `json:"jobs"`
+	Errors []*JobError `json:"errors,omitempty"`
+}
+
+// NewScrapeResult creates an empty ScrapeResult.
+func NewScrapeResult() *ScrapeResult {
+	return &ScrapeResult{
+		Jobs: make([]*Job, 0),
+	}
+}
+
+// AddJob records a successfully scraped job.
+func (r *ScrapeResult) AddJob(job *Job) {
+	r.Jobs = append(r.Jobs, job)
+}
+
+// AddError records a job that failed at the given stage.
+func (r *ScrapeResult) AddError(jobID string, stage ScrapeStage, err error) {
+	r.Errors = append(r.Errors, &JobError{JobID: jobID, Stage: stage, Err: err})
+}
+
+// Partial reports whether any job failed, meaning Jobs may be missing postings the
+// company still has open.
+func (r *ScrapeResult) Partial() bool {
+	for _, jobErr := range r.Errors {
+		if jobErr.Dropped() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err joins every recorded job error, or returns nil if there are none.
+func (r *ScrapeResult) Err() error {
+	errs := make([]error, 0, len(r.Errors))
+	for _, jobErr := range r.Errors {
+		errs = append(errs, jobErr)
+	}
+
+	return errors.Join(errs...)
+}
+
+// CollectResult drains a job stream, as returned by the loaders' StreamCompany functions,
+// into a ScrapeResult. Jobs and *JobError values are recorded as they arrive; any other
+// error ends the stream and is returned together with the jobs collected so far.
+func CollectResult(jobs iter.Seq2[*Job, error]) (*ScrapeResult, error) {
+	result := NewScrapeResult()
+
+	for job, err := range jobs {
+		if err != nil {
+			var jobErr *JobError
+			if !errors.As(err, &jobErr) {
+				return result, err
+			}
+
+			result.Errors = append(result.Errors, jobErr)
+
+			continue
+		}
+
+		result.AddJob(job)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+	"testing"
+)
+
+func TestScrapeResult(t *testing.T) {
+	t.Parallel()
+
+	result := NewScrapeResult()
+	result.AddJob(NewJob("lever", nil))
+	result.AddError("abc", StageCompanyInfo, ErrBoardNotFound)
+
+	if result.Partial() {
+		t.Errorf("Partial() with only company info errors = true, want false")
+	}
+
+	result.AddError("def", StageDetail, ErrJobGone)
+
+	if !result.Partial() {
+		t.Errorf("Partial() with a detail error = false, want true")
+	}
+
+	err := result.Err()
+	if !errors.Is(err, ErrJobGone) || !errors.Is(err, ErrBoardNotFound) {
+		t.Errorf("Err() = %v, want it to wrap both job errors", err)
+	}
+
+	data, err := json.Marshal(result.Errors[1])
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"job_id":"def","stage":"detail","error":"job posting no longer exists"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	if NewScrapeResult().Err() != nil {
+		t.Errorf("Err() of an empty result = %v, want nil", NewScrapeResult().Err())
+	}
+}
+
+func TestCollectResult(t *testing.T) {
+	t.Parallel()
+
+	errBoard := errors.New("board is down")
+
+	stream := func(fatal bool) iter.Seq2[*Job, error] {
+		return func(yield func(*Job, error) bool) {
+			if !yield(NewJob("lever", nil), nil) {
+				return
+			}
+
+			if !yield(nil, &JobError{JobID: "abc", Stage: StageDetail, Err: ErrJobGone}) {
+				return
+			}
+
+			if fatal {
+				yield(nil, errBoard)
+				return
+			}
+
+			yield(NewJob("lever", nil), nil)
+		}
+	}
+
+	result, err := CollectResult(stream(false))
+	if err != nil {
+		t.Fatalf("CollectResult() error = %v, want nil", err)
+	}
+
+	if len(result.Jobs) != 2 || len(result.Errors) != 1 {
+		t.Errorf("CollectResult() = %d jobs and %d errors, want 2 and 1", len(result.Jobs), len(result.Errors))
+	}
+
+	result, err = CollectResult(stream(true))
+	if !errors.Is(err, errBoard) {
+		t.Errorf("CollectResult() error = %v, want %v", err, errBoard)
+	}
+
+	if len(result.Jobs) != 1 || len(result.Errors) != 1 {
+		t.Errorf("CollectResult() = %d jobs and %d errors, want 1 and 1", len(result.Jobs), len(result.Errors))
+	}
+}
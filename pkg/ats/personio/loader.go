@@ -0,0 +1,297 @@
+// Package personio provides functions to scrape job postings from the XML feeds of Personio
+// careers sites.
+//
+// Personio publishes every open position of a company in a single XML feed, so a company is
+// scraped with one request and a single job is looked up in the same feed.
+package personio
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"iter"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+)
+
+// Source identifies jobs scraped from Personio and is the name the loader is registered under.
+const Source = "personio"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Personio feeds; use New to point it elsewhere.
+type Loader struct {
+	companyURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithCompanyURL sets the format of a company's careers site URL, with %s standing for the
+// company name, DefaultCompanyURL by default.
+func WithCompanyURL(format string) Option {
+	return func(l *Loader) {
+		l.companyURL = strings.TrimSuffix(format, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Personio.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Personio, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Personio, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Personio given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.companyURL == "" {
+		l.companyURL = DefaultCompanyURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultCompanyURL is the format of a company's careers site URL, with %s standing for
+	// the company name.
+	DefaultCompanyURL = "https://%s.jobs.personio.de"
+
+	personioFeedURL = "%s/xml"
+	personioJobURL  = "%s/job/%s"
+)
+
+// feed is a Personio XML feed.
+type feed struct {
+	Positions []position `xml:"position"`
+}
+
+// position is a single open position in a Personio XML feed.
+type position struct {
+	ID                 string           `xml:"id"`
+	Subcompany         string           `xml:"subcompany"`
+	Office             string           `xml:"office"`
+	AdditionalOffices  []string         `xml:"additionalOffices>office"`
+	Department         string           `xml:"department"`
+	RecruitingCategory string           `xml:"recruitingCategory"`
+	Name               string           `xml:"name"`
+	JobDescriptions    []jobDescription `xml:"jobDescriptions>jobDescription"`
+	EmploymentType     string           `xml:"employmentType"`
+	Seniority          string           `xml:"seniority"`
+	Schedule           string           `xml:"schedule"`
+	YearsOfExperience  string           `xml:"yearsOfExperience"`
+	Keywords           string           `xml:"keywords"`
+	Occupation         string           `xml:"occupation"`
+	OccupationCategory string           `xml:"occupationCategory"`
+	CreatedAt          string           `xml:"createdAt"`
+
+	// Raw is the position's XML, kept as the job's source data.
+	Raw []byte `xml:",innerxml"`
+}
+
+// jobDescription is one titled section of a position's description.
+type jobDescription struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value"`
+}
+
+// companyURL returns the careers site URL of the named company.
+func companyURL(ctx context.Context, companyName string) string {
+	return fmt.Sprintf(endpoints(ctx).companyURL, companyName)
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Personio.
+func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	result, err := ScrapeCompanyResult(ctx, companyName)
+
+	return result.Jobs, err
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Personio, reporting the jobs
+// that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
+
+// StreamCompany scrapes all jobs for a given company from Personio, yielding each job as it
+// is parsed. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "personio"), slog.String("company_name", companyName))
+
+		positions, err := getFeed(ctx, companyName)
+		if err != nil {
+			yield(nil, fmt.Errorf("error getting Personio feed: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
+			return
+		}
+
+		for _, position := range positions {
+			if !yield(parsePersonioJob(ctx, companyURL(ctx, companyName), position), nil) {
+				return
+			}
+		}
+	}
+}
+
+// ScrapeJob scrapes an individual job from Personio given the company name and job ID.
+// Personio has no endpoint for a single position, so it is looked up in the company's feed.
+func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
+	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "personio"), slog.String("company_name", companyName), slog.String("job_id", jobID))
+
+	positions, err := getFeed(ctx, companyName)
+	if err != nil {
+		// a missing feed means the board is gone, not the job
+		return nil, fmt.Errorf("error getting Personio feed: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound))
+	}
+
+	for _, position := range positions {
+		if position.ID == jobID {
+			return parsePersonioJob(ctx, companyURL(ctx, companyName), position), nil
+		}
+	}
+
+	return nil, fmt.Errorf("job %s is not in the Personio feed: %w", jobID, models.ErrJobGone)
+}
+
+// getFeed fetches and decodes the company's XML feed.
+func getFeed(ctx context.Context, companyName string) ([]position, error) {
+	// The URL is like https://{companyName}.jobs.personio.de/xml
+	feedURL := fmt.Sprintf(personioFeedURL, companyURL(ctx, companyName))
+
+	body, err := helpers.GetHTML(ctx, feedURL)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting Personio XML feed", slog.String("url", feedURL), slog.Any("error", err))
+		return nil, fmt.Errorf("error getting Personio XML feed: %w", err)
+	}
+
+	var positions feed
+
+	err = xml.Unmarshal(body, &positions)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing Personio XML feed", slog.String("url", feedURL), slog.Any("error", err))
+		return nil, fmt.Errorf("error parsing Personio XML feed: %w", err)
+	}
+
+	return positions.Positions, nil
+}
+
+func parsePersonioJob(ctx context.Context, companyURL string, p position) *models.Job {
+	job := models.NewJob(Source, p.Raw)
+
+	job.SourceID = p.ID
+	job.Title = p.Name
+	job.URL = fmt.Sprintf(personioJobURL, companyURL, p.ID)
+	job.Company.Name = p.Subcompany
+	job.Location = p.Office
+	job.EmploymentType = parseEmploymentType(p.EmploymentType, p.Schedule)
+
+	if p.Department != "" {
+		job.Department = models.ParseDepartment(p.Department)
+		job.DepartmentRaw = p.Department
+	}
+
+	// Personio has no remote flag, but companies list remote work as an office
+	for _, office := range append([]string{p.Office}, p.AdditionalOffices...) {
+		if strings.Contains(strings.ToLower(office), "remote") {
+			job.LocationType = models.RemoteLocation
+			job.IsRemote = true
+		}
+	}
+
+	for _, office := range p.AdditionalOffices {
+		job.AddMetadata("additionalOffices", office)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, p.CreatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing createdAt", slog.String("created_at", p.CreatedAt), slog.Any("error", err))
+		// we continue even if there's an error here
+	} else {
+		job.DatePosted = createdAt.UTC()
+	}
+
+	var description strings.Builder
+
+	for _, section := range p.JobDescriptions {
+		if strings.TrimSpace(section.Value) == "" {
+			continue
+		}
+
+		if section.Name != "" {
+			description.WriteString("<h3>" + html.EscapeString(section.Name) + "</h3>")
+		}
+
+		description.WriteString(strings.TrimSpace(section.Value))
+	}
+
+	job.Description = description.String()
+
+	job.AddMetadata("employmentType", p.EmploymentType)
+	job.AddMetadata("schedule", p.Schedule)
+	job.AddMetadata("seniority", p.Seniority)
+	job.AddMetadata("yearsOfExperience", p.YearsOfExperience)
+	job.AddMetadata("keywords", p.Keywords)
+	job.AddMetadata("recruitingCategory", p.RecruitingCategory)
+	job.AddMetadata("occupation", p.Occupation)
+	job.AddMetadata("occupationCategory", p.OccupationCategory)
+
+	return job
+}
+
+// parseEmploymentType maps a position's employmentType, such as permanent, intern or
+// freelance, and its schedule, such as full-time or part-time, to an EmploymentType. The
+// employment type wins when it says more than that the job is permanent.
+func parseEmploymentType(employmentType, schedule string) models.EmploymentType {
+	switch employmentType {
+	case "freelance":
+		employmentType = "contract"
+	case "trainee":
+		employmentType = "internship"
+	}
+
+	parsed := models.ParseEmploymentType(employmentType)
+	if parsed != models.UnknownEmploymentType {
+		return parsed
+	}
+
+	return models.ParseEmploymentType(schedule)
+}
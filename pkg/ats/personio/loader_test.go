@@ -0,0 +1,178 @@
+package personio
+
+import (
+	"context"
+	_ "embed"
+	"encoding/xml"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/h2non/gock"
+)
+
+//go:embed job_list.xml
+var jobList string
+
+func Test_parsePersonioJob(t *testing.T) {
+	t.Parallel()
+
+	var positions feed
+
+	err := xml.Unmarshal([]byte(jobList), &positions)
+	if err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+
+	if len(positions.Positions) != 3 {
+		t.Fatalf("xml.Unmarshal() positions = %v, want 3", len(positions.Positions))
+	}
+
+	job := parsePersonioJob(context.Background(), "https://acme.jobs.personio.de", positions.Positions[0])
+
+	if job.SourceID != "1482093" {
+		t.Errorf("parsePersonioJob() SourceID = %v, want %v", job.SourceID, "1482093")
+	}
+
+	if job.Title != "Senior Backend Engineer (m/w/d)" {
+		t.Errorf("parsePersonioJob() Title = %v, want %v", job.Title, "Senior Backend Engineer (m/w/d)")
+	}
+
+	if job.URL != "https://acme.jobs.personio.de/job/1482093" {
+		t.Errorf("parsePersonioJob() URL = %v, want %v", job.URL, "https://acme.jobs.personio.de/job/1482093")
+	}
+
+	if job.Company.Name != "Acme GmbH" {
+		t.Errorf("parsePersonioJob() Company.Name = %v, want %v", job.Company.Name, "Acme GmbH")
+	}
+
+	if job.Location != "Berlin" {
+		t.Errorf("parsePersonioJob() Location = %v, want %v", job.Location, "Berlin")
+	}
+
+	// one of the additional offices is remote
+	if job.LocationType != models.RemoteLocation || !job.IsRemote {
+		t.Errorf("parsePersonioJob() LocationType = %v, IsRemote = %v, want remote", job.LocationType, job.IsRemote)
+	}
+
+	if job.EmploymentType != models.FullTime {
+		t.Errorf("parsePersonioJob() EmploymentType = %v, want %v", job.EmploymentType, models.FullTime)
+	}
+
+	if job.DepartmentRaw != "Engineering" {
+		t.Errorf("parsePersonioJob() DepartmentRaw = %v, want %v", job.DepartmentRaw, "Engineering")
+	}
+
+	if want := time.Date(2026, 9, 22, 8, 41, 17, 0, time.UTC); !job.DatePosted.Equal(want) {
+		t.Errorf("parsePersonioJob() DatePosted = %v, want %v", job.DatePosted, want)
+	}
+
+	want := "<h3>Your mission</h3><p>Build the services that keep our anvils shipping across Europe.</p>" +
+		"<h3>Your profile</h3><ul><li>5+ years of backend development in Go or Java</li><li>Fluent English, German is a plus</li></ul>"
+	if job.Description != want {
+		t.Errorf("parsePersonioJob() Description = %v, want %v", job.Description, want)
+	}
+
+	// metadata values come back in no particular order
+	if got := job.GetMetadata("keywords"); !slices.Equal(slices.Sorted(slices.Values(got)), []string{"Go", "Kubernetes", "PostgreSQL"}) {
+		t.Errorf("parsePersonioJob() keywords = %v, want [Go Kubernetes PostgreSQL]", got)
+	}
+
+	if got := job.GetMetadata("seniority"); len(got) != 1 || got[0] != "experienced" {
+		t.Errorf("parsePersonioJob() seniority = %v, want [experienced]", got)
+	}
+
+	if !strings.Contains(string(job.GetSourceData()), "<id>1482093</id>") {
+		t.Errorf("parsePersonioJob() source data = %s, want the position's XML", job.GetSourceData())
+	}
+}
+
+func Test_parseEmploymentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		employmentType string
+		schedule       string
+		want           models.EmploymentType
+	}{
+		{employmentType: "permanent", schedule: "full-time", want: models.FullTime},
+		{employmentType: "permanent", schedule: "part-time", want: models.PartTime},
+		{employmentType: "intern", schedule: "full-time", want: models.Internship},
+		{employmentType: "trainee", schedule: "full-time", want: models.Internship},
+		{employmentType: "freelance", schedule: "part-time", want: models.Contract},
+		{employmentType: "temporary", schedule: "full-time", want: models.Temporary},
+		{employmentType: "working_student", schedule: "part-time", want: models.PartTime},
+		{employmentType: "permanent", schedule: "full-or-part-time", want: models.UnknownEmploymentType},
+	}
+
+	for _, tt := range tests {
+		if got := parseEmploymentType(tt.employmentType, tt.schedule); got != tt.want {
+			t.Errorf("parseEmploymentType(%q, %q) = %v, want %v", tt.employmentType, tt.schedule, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeCompany(t *testing.T) {
+	t.Parallel()
+
+	defer gock.Off() // Flush pending mocks after test execution
+
+	gock.New("https://acme.jobs.personio.de").
+		Get("/xml").
+		Reply(200).
+		Type("application/xml").
+		BodyString(jobList)
+
+	jobs, err := ScrapeCompany(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 3 {
+		t.Fatalf("ScrapeCompany() len(jobs) = %v, want 3", len(jobs))
+	}
+
+	if jobs[1].EmploymentType != models.Internship || jobs[2].EmploymentType != models.PartTime {
+		t.Errorf("ScrapeCompany() employment types = %v, %v, want internship and part-time", jobs[1].EmploymentType, jobs[2].EmploymentType)
+	}
+
+	gock.New("https://missing.jobs.personio.de").
+		Get("/xml").
+		Reply(404)
+
+	_, err = ScrapeCompany(context.Background(), "missing")
+	if !errors.Is(err, models.ErrBoardNotFound) {
+		t.Errorf("ScrapeCompany() error = %v, want %v", err, models.ErrBoardNotFound)
+	}
+
+	for range 2 {
+		gock.New("https://acme.jobs.personio.de").
+			Get("/xml").
+			Reply(200).
+			Type("application/xml").
+			BodyString(jobList)
+	}
+
+	job, err := ScrapeJob(context.Background(), "acme", "1490311")
+	if err != nil || job.Title != "Marketing Intern (m/w/d)" {
+		t.Errorf("ScrapeJob() = %v, %v, want the marketing intern", job, err)
+	}
+
+	_, err = ScrapeJob(context.Background(), "acme", "999")
+	if !errors.Is(err, models.ErrJobGone) {
+		t.Errorf("ScrapeJob() error = %v, want %v", err, models.ErrJobGone)
+	}
+
+	// a missing feed is a missing board, so it mustn't close the job
+	gock.New("https://missing.jobs.personio.de").
+		Get("/xml").
+		Reply(404)
+
+	_, err = ScrapeJob(context.Background(), "missing", "1490311")
+	if !errors.Is(err, models.ErrBoardNotFound) || errors.Is(err, models.ErrJobGone) {
+		t.Errorf("ScrapeJob() error = %v, want %v", err, models.ErrBoardNotFound)
+	}
+}
@@ -0,0 +1,324 @@
+// Package recruitee provides functions to scrape job offers from Recruitee careers sites.
+//
+// Recruitee's offers endpoint returns every published offer of a company in full, so a
+// company is scraped with a single request and no per-job detail calls.
+package recruitee
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+	"github.com/buger/jsonparser"
+)
+
+// Source identifies jobs scraped from Recruitee and is the name the loader is registered under.
+const Source = "recruitee"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Recruitee endpoints; use New to point it elsewhere.
+type Loader struct {
+	companyURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithCompanyURL sets the format of a company's careers site URL, with %s standing for the
+// company name, DefaultCompanyURL by default.
+func WithCompanyURL(format string) Option {
+	return func(l *Loader) {
+		l.companyURL = strings.TrimSuffix(format, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Recruitee.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Recruitee, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Recruitee, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Recruitee given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.companyURL == "" {
+		l.companyURL = DefaultCompanyURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultCompanyURL is the format of a company's careers site URL, with %s standing for
+	// the company name.
+	DefaultCompanyURL = "https://%s.recruitee.com"
+
+	recruiteeOffersURL = "%s/api/offers/"
+	recruiteeOfferURL  = "%s/api/offers/%s"
+
+	// publishedAtLayout is the layout of an offer's published_at, e.g. 2026-09-28 10:15:00 UTC.
+	publishedAtLayout = "2006-01-02 15:04:05 MST"
+)
+
+// companyURL returns the careers site URL of the named company.
+func companyURL(ctx context.Context, companyName string) string {
+	return fmt.Sprintf(endpoints(ctx).companyURL, companyName)
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Recruitee.
+func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	result, err := ScrapeCompanyResult(ctx, companyName)
+
+	return result.Jobs, err
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Recruitee, reporting the
+// jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
+
+// StreamCompany scrapes all jobs for a given company from Recruitee, yielding each job as it
+// is parsed. Jobs that fail are yielded as a *models.JobError and the stream carries on; any
+// other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "recruitee"), slog.String("company_name", companyName))
+
+		// The URL is like https://{companyName}.recruitee.com/api/offers/
+		offersURL := fmt.Sprintf(recruiteeOffersURL, companyURL(ctx, companyName))
+
+		body, err := helpers.GetJSON(ctx, offersURL, nil)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error getting JSON from Recruitee offers endpoint", slog.String("url", offersURL), slog.Any("error", err))
+			yield(nil, fmt.Errorf("error getting JSON from Recruitee offers endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
+
+			return
+		}
+
+		stopped := false
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			if stopped {
+				return
+			}
+
+			job, jerr := parseRecruiteeJob(ctx, value)
+			if jerr != nil {
+				slog.ErrorContext(ctx, "Error parsing Recruitee job from offers array", slog.Any("error", jerr))
+				jobID, _, _, _ := jsonparser.Get(value, "id")
+				stopped = !yield(nil, &models.JobError{JobID: string(jobID), Stage: models.StageList, Err: jerr})
+
+				return
+			}
+
+			stopped = !yield(job, nil)
+		}, "offers")
+		if err != nil && !stopped {
+			slog.ErrorContext(ctx, "Error parsing offers array from Recruitee offers endpoint", slog.Any("error", err))
+			yield(nil, fmt.Errorf("error parsing offers array: %w", err))
+		}
+	}
+}
+
+// ScrapeJob scrapes an individual job from Recruitee given the company name and job ID.
+// The job ID may be the offer's numeric ID or its slug.
+func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
+	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "recruitee"), slog.String("company_name", companyName), slog.String("job_id", jobID))
+
+	// The URL is like https://{companyName}.recruitee.com/api/offers/{jobID}
+	offerURL := fmt.Sprintf(recruiteeOfferURL, companyURL(ctx, companyName), jobID)
+
+	body, err := helpers.GetJSON(ctx, offerURL, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting JSON from Recruitee offer endpoint", slog.String("url", offerURL), slog.Any("error", err))
+		return nil, fmt.Errorf("error getting JSON from Recruitee offer endpoint: %w", helpers.WrapNotFound(err, models.ErrJobGone))
+	}
+
+	offer, _, _, err := jsonparser.Get(body, "offer")
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting offer from Recruitee offer endpoint", slog.Any("error", err))
+		return nil, fmt.Errorf("error getting offer from Recruitee offer endpoint: %w", err)
+	}
+
+	job, err := parseRecruiteeJob(ctx, offer)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing Recruitee job from offer endpoint", slog.Any("error", err))
+		return nil, fmt.Errorf("error parsing Recruitee job from offer endpoint: %w", err)
+	}
+
+	return job, nil
+}
+
+func parseRecruiteeJob(ctx context.Context, data []byte) (*models.Job, error) {
+	job := models.NewJob(Source, data)
+
+	var (
+		requirements           string
+		remote, hybrid, onSite bool
+	)
+
+	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, dataType jsonparser.ValueType, _ int) error {
+		switch string(key) {
+		case "id":
+			job.SourceID = string(value)
+		case "title":
+			job.Title = string(value)
+		case "description":
+			job.Description = string(value)
+		case "requirements":
+			requirements = string(value)
+		case "careers_url":
+			job.URL = string(value)
+		case "company_name":
+			job.Company.Name = string(value)
+		case "department":
+			job.Department = models.ParseDepartment(string(value))
+			job.DepartmentRaw = string(value)
+		case "location":
+			job.Location = string(value)
+		case "locations":
+			_, err := jsonparser.ArrayEach(value, func(location []byte, _ jsonparser.ValueType, _ int, _ error) {
+				job.AddMetadata("locations", models.ParseLocation(location).String())
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "Error parsing locations array", slog.Any("error", err))
+				return fmt.Errorf("error parsing locations: %w", err)
+			}
+		case "employment_type_code":
+			job.EmploymentType = parseEmploymentType(string(value))
+			job.AddMetadata("employment_type_code", string(value))
+		case "remote":
+			remote = string(value) == "true"
+		case "hybrid":
+			hybrid = string(value) == "true"
+		case "on_site":
+			onSite = string(value) == "true"
+		case "salary":
+			parseSalary(job, value)
+		case "published_at":
+			publishedAt, err := time.Parse(publishedAtLayout, string(value))
+			if err != nil {
+				slog.ErrorContext(ctx, "Error parsing published_at", slog.Any("error", err))
+				// we continue even if there's an error here
+			} else {
+				job.DatePosted = publishedAt.UTC()
+			}
+		default:
+			if dataType == jsonparser.String || dataType == jsonparser.Number || dataType == jsonparser.Boolean {
+				job.AddMetadata(string(key), string(value))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Recruitee job object: %w", err)
+	}
+
+	// an offer can allow more than one kind of workplace; the most flexible one wins
+	switch {
+	case remote:
+		job.LocationType = models.RemoteLocation
+		job.IsRemote = true
+	case hybrid:
+		job.LocationType = models.HybridLocation
+	case onSite:
+		job.LocationType = models.OnsiteLocation
+	}
+
+	// requirements follow the description as a section of their own
+	if strings.TrimSpace(requirements) != "" {
+		job.Description += "<h3>Requirements</h3>" + strings.TrimSpace(requirements)
+	}
+
+	return job, nil
+}
+
+// parseEmploymentType maps an employment_type_code, such as fulltime_permanent or
+// parttime_fixed_term, to an EmploymentType.
+func parseEmploymentType(code string) models.EmploymentType {
+	code, _, _ = strings.Cut(code, "_")
+
+	switch code {
+	case "freelance":
+		return models.Contract
+	case "traineeship", "apprenticeship":
+		return models.Internship
+	default:
+		return models.ParseEmploymentType(code)
+	}
+}
+
+// parseSalary sets the job's compensation from an offer's salary object, whose amounts may
+// be numbers or numeric strings.
+func parseSalary(job *models.Job, value []byte) {
+	amount := func(key string) float64 {
+		raw, _, _, err := jsonparser.Get(value, key)
+		if err != nil {
+			return 0
+		}
+
+		f, err := strconv.ParseFloat(string(raw), 64)
+		if err != nil {
+			return 0
+		}
+
+		return f
+	}
+
+	job.MinCompensation = amount("min")
+	job.MaxCompensation = amount("max")
+
+	currency, err := jsonparser.GetString(value, "currency")
+	if err == nil {
+		job.CompensationUnit = currency
+	}
+
+	period, err := jsonparser.GetString(value, "period")
+	if err == nil {
+		job.AddMetadata("compensation_interval", period)
+	}
+}
@@ -0,0 +1,165 @@
+package recruitee
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/buger/jsonparser"
+	"github.com/h2non/gock"
+)
+
+//go:embed single_job.json
+var singleJob string
+
+//go:embed job_list.json
+var jobList string
+
+func Test_parseRecruiteeJob(t *testing.T) {
+	t.Parallel()
+
+	offer, _, _, err := jsonparser.Get([]byte(singleJob), "offer")
+	if err != nil {
+		t.Fatalf("jsonparser.Get() error = %v", err)
+	}
+
+	job, err := parseRecruiteeJob(context.Background(), offer)
+	if err != nil {
+		t.Fatalf("parseRecruiteeJob() error = %v", err)
+	}
+
+	if job.SourceID != "1894521" {
+		t.Errorf("parseRecruiteeJob() SourceID = %v, want %v", job.SourceID, "1894521")
+	}
+
+	if job.Title != "Senior Frontend Engineer" {
+		t.Errorf("parseRecruiteeJob() Title = %v, want %v", job.Title, "Senior Frontend Engineer")
+	}
+
+	if job.EmploymentType != models.FullTime {
+		t.Errorf("parseRecruiteeJob() EmploymentType = %v, want %v", job.EmploymentType, models.FullTime)
+	}
+
+	// the offer allows both hybrid and on-site work
+	if job.LocationType != models.HybridLocation || job.IsRemote {
+		t.Errorf("parseRecruiteeJob() LocationType = %v, IsRemote = %v, want hybrid", job.LocationType, job.IsRemote)
+	}
+
+	if job.MinCompensation != 65000 || job.MaxCompensation != 80000 || job.CompensationUnit != "EUR" {
+		t.Errorf("parseRecruiteeJob() compensation = %v-%v %v, want 65000-80000 EUR", job.MinCompensation, job.MaxCompensation, job.CompensationUnit)
+	}
+
+	if want := time.Date(2026, 9, 28, 10, 15, 0, 0, time.UTC); !job.DatePosted.Equal(want) {
+		t.Errorf("parseRecruiteeJob() DatePosted = %v, want %v", job.DatePosted, want)
+	}
+
+	if job.Location != "Amsterdam, Netherlands" {
+		t.Errorf("parseRecruiteeJob() Location = %v, want %v", job.Location, "Amsterdam, Netherlands")
+	}
+
+	if got := job.GetMetadata("locations"); !slices.Contains(got, "Rotterdam") {
+		t.Errorf("parseRecruiteeJob() locations = %v, want Rotterdam among them", got)
+	}
+
+	if job.DepartmentRaw != "Engineering" {
+		t.Errorf("parseRecruiteeJob() DepartmentRaw = %v, want %v", job.DepartmentRaw, "Engineering")
+	}
+
+	if job.Company.Name != "Acme" {
+		t.Errorf("parseRecruiteeJob() Company.Name = %v, want %v", job.Company.Name, "Acme")
+	}
+
+	if job.URL != "https://acme.recruitee.com/o/senior-frontend-engineer" {
+		t.Errorf("parseRecruiteeJob() URL = %v", job.URL)
+	}
+
+	want := "<p>Help us build the tools our customers use to hire their teams.</p><h3>Requirements</h3><ul><li>5+ years of experience with TypeScript</li><li>Experience with React</li></ul>"
+	if job.Description != want {
+		t.Errorf("parseRecruiteeJob() Description = %v, want %v", job.Description, want)
+	}
+}
+
+func Test_parseEmploymentType(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]models.EmploymentType{
+		"fulltime":            models.FullTime,
+		"fulltime_permanent":  models.FullTime,
+		"parttime_fixed_term": models.PartTime,
+		"freelance":           models.Contract,
+		"traineeship":         models.Internship,
+		"volunteer":           models.UnknownEmploymentType,
+	}
+
+	for code, want := range tests {
+		if got := parseEmploymentType(code); got != want {
+			t.Errorf("parseEmploymentType(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestScrapeCompany(t *testing.T) {
+	t.Parallel()
+
+	defer gock.Off() // Flush pending mocks after test execution
+
+	gock.New("https://acme.recruitee.com").
+		Get("/api/offers/").
+		Reply(200).
+		JSON(jobList)
+
+	jobs, err := ScrapeCompany(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 3 {
+		t.Fatalf("ScrapeCompany() len(jobs) = %v, want 3", len(jobs))
+	}
+
+	remote := jobs[1]
+	if remote.LocationType != models.RemoteLocation || !remote.IsRemote || remote.EmploymentType != models.PartTime {
+		t.Errorf("ScrapeCompany() jobs[1] = %v, %v, want a remote part-time job", remote.LocationType, remote.EmploymentType)
+	}
+
+	if remote.MinCompensation != 0 || remote.CompensationUnit != "" {
+		t.Errorf("ScrapeCompany() jobs[1] compensation = %v %v, want none", remote.MinCompensation, remote.CompensationUnit)
+	}
+
+	trainee := jobs[2]
+	if trainee.LocationType != models.OnsiteLocation || trainee.MinCompensation != 1200 {
+		t.Errorf("ScrapeCompany() jobs[2] = %v, %v, want an on-site job paying 1200", trainee.LocationType, trainee.MinCompensation)
+	}
+
+	gock.New("https://missing.recruitee.com").
+		Get("/api/offers/").
+		Reply(404)
+
+	_, err = ScrapeCompany(context.Background(), "missing")
+	if !errors.Is(err, models.ErrBoardNotFound) {
+		t.Errorf("ScrapeCompany() error = %v, want %v", err, models.ErrBoardNotFound)
+	}
+
+	gock.New("https://acme.recruitee.com").
+		Get("/api/offers/senior-frontend-engineer").
+		Reply(200).
+		JSON(singleJob)
+
+	job, err := ScrapeJob(context.Background(), "acme", "senior-frontend-engineer")
+	if err != nil || job.SourceID != "1894521" {
+		t.Errorf("ScrapeJob() = %v, %v, want job 1894521", job, err)
+	}
+
+	gock.New("https://acme.recruitee.com").
+		Get("/api/offers/closed-role").
+		Reply(404)
+
+	_, err = ScrapeJob(context.Background(), "acme", "closed-role")
+	if !errors.Is(err, models.ErrJobGone) {
+		t.Errorf("ScrapeJob() error = %v, want %v", err, models.ErrJobGone)
+	}
+}
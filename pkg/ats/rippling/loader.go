@@ -4,75 +4,197 @@ package rippling
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"net/url"
+	"strings"
 
 	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
 	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
 	"github.com/buger/jsonparser"
 )
 
-var (
-	ripplingCompanyURL = "https://ats.rippling.com/api/v2/board/%s/jobs"
-	ripplingJobURL     = "https://ats.rippling.com/api/v2/board/%s/jobs/%s"
+// Source identifies jobs scraped from Rippling and is the name the loader is registered under.
+const Source = "rippling"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Rippling endpoints; use New to point it elsewhere.
+type Loader struct {
+	baseURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithBaseURL sets the base URL of the job board API, DefaultBaseURL by default.
+func WithBaseURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Rippling.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Rippling, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Rippling, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Rippling given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.baseURL == "" {
+		l.baseURL = DefaultBaseURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultBaseURL is the base URL of the Rippling job board API.
+	DefaultBaseURL = "https://ats.rippling.com"
+
+	ripplingCompanyURL = "%s/api/v2/board/%s/jobs"
+	ripplingJobURL     = "%s/api/v2/board/%s/jobs/%s"
 )
 
 // ScrapeCompany scrapes all job listings for a given company from Rippling ATS.
 func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
-	slog.DebugContext(ctx, "Scraping company", slog.String("ats", "rippling"), slog.String("company_name", companyName))
+	result, err := ScrapeCompanyResult(ctx, companyName)
+
+	return result.Jobs, err
+}
 
-	jobs := make([]*models.Job, 0)
+// ScrapeCompanyResult scrapes all job listings for a given company from Rippling ATS, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
 
-	// The URL is like https://ats.rippling.com/api/v2/board/%s/jobs
-	companyURL := fmt.Sprintf(ripplingCompanyURL, companyName)
+// StreamCompany scrapes all job listings for a given company from Rippling ATS, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
 
-	body, err := helpers.GetJSON(ctx, companyURL, nil)
-	if err != nil {
-		slog.ErrorContext(ctx, "Error getting JSON from Rippling job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
-		return jobs, fmt.Errorf("error getting JSON from Rippling job board endpoint: %w", err)
-	}
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "rippling"), slog.String("company_name", companyName))
 
-	_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
-		jobID, err := jsonparser.GetString(value, "id")
+		// The URL is like https://ats.rippling.com/api/v2/board/%s/jobs
+		companyURL := fmt.Sprintf(ripplingCompanyURL, endpoints(ctx).baseURL, companyName)
+
+		body, err := helpers.GetJSON(ctx, companyURL, nil)
 		if err != nil {
-			slog.ErrorContext(ctx, "Error parsing job ID from jobs array", slog.Any("error", err))
+			slog.ErrorContext(ctx, "Error getting JSON from Rippling job board endpoint", slog.String("url", companyURL), slog.Any("error", err))
+			yield(nil, fmt.Errorf("error getting JSON from Rippling job board endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
+
 			return
 		}
 
-		job, jerr := ScrapeJob(ctx, companyName, jobID)
-		if jerr != nil {
-			slog.ErrorContext(ctx, "Error parsing rippling job from jobs array", slog.Any("error", jerr))
+		jobIDs := make([]string, 0)
+		listErrs := make([]error, 0)
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			jobID, err := jsonparser.GetString(value, "id")
+			if err != nil {
+				slog.ErrorContext(ctx, "Error parsing job ID from jobs array", slog.Any("error", err))
+				listErrs = append(listErrs, &models.JobError{Stage: models.StageList, Err: fmt.Errorf("error parsing job ID from jobs array: %w", err)})
+
+				return
+			}
+
+			jobIDs = append(jobIDs, jobID)
+		}, "items")
+		if err != nil {
+			slog.ErrorContext(ctx, "Error parsing jobs array from Rippling job board endpoint", slog.Any("error", err))
+			yield(nil, fmt.Errorf("error parsing jobs array from Rippling job board endpoint: %w", err))
+
 			return
 		}
 
-		jobs = append(jobs, job)
-	}, "items")
-	if err != nil {
-		slog.ErrorContext(ctx, "Error parsing jobs array from Rippling job board endpoint", slog.Any("error", err))
-		return jobs, fmt.Errorf("error parsing jobs array from Rippling job board endpoint: %w", err)
-	}
+		for _, err := range listErrs {
+			if !yield(nil, err) {
+				return
+			}
+		}
+
+		jobs := helpers.FetchEach(ctx, jobIDs, func(ctx context.Context, jobID string) (*models.Job, error) {
+			job, err := ScrapeJob(ctx, companyName, jobID)
+			if err != nil {
+				slog.ErrorContext(ctx, "Error parsing rippling job from jobs array", slog.String("job_id", jobID), slog.Any("error", err))
+				return nil, &models.JobError{JobID: jobID, Stage: models.StageDetail, Err: err}
+			}
+
+			return job, nil
+		})
+
+		for job, err := range jobs {
+			if err != nil && ctx.Err() != nil {
+				break
+			}
+
+			if !yield(job, err) {
+				return
+			}
+		}
 
-	return jobs, nil
+		if err := ctx.Err(); err != nil {
+			yield(nil, fmt.Errorf("error scraping Rippling jobs: %w", err))
+		}
+	}
 }
 
 // ScrapeJob scrapes an individual job listing from Rippling ATS.
 func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "rippling"), slog.String("company_name", companyName), slog.String("job_id", jobID))
 
 	// The URL is like https://ats.rippling.com/api/v2/board/smartwyre/jobs/698a497a-ab01-48dc-9517-3d25704cc32c
-	jobURL := fmt.Sprintf(ripplingJobURL, companyName, jobID)
+	jobURL := fmt.Sprintf(ripplingJobURL, endpoints(ctx).baseURL, companyName, jobID)
 
 	body, err := helpers.GetJSON(ctx, jobURL, nil)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error getting JSON from Rippling job endpoint", slog.String("url", jobURL), slog.Any("error", err))
-		return nil, fmt.Errorf("error getting JSON from Rippling job endpoint: %w", err)
+		return nil, fmt.Errorf("error getting JSON from Rippling job endpoint: %w", helpers.WrapNotFound(err, models.ErrJobGone))
 	}
 
 	return parseRipplingJob(ctx, body)
 }
 
 func parseRipplingJob(ctx context.Context, data []byte) (*models.Job, error) {
-	job := models.NewJob("rippling", data)
+	job := models.NewJob(Source, data)
 
 	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
 		switch string(key) {
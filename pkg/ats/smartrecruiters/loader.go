@@ -0,0 +1,357 @@
+// Package smartrecruiters provides functions to scrape job postings from the SmartRecruiters
+// public posting API.
+package smartrecruiters
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"iter"
+	"log/slog"
+	"strings"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+	"github.com/buger/jsonparser"
+)
+
+// Source identifies jobs scraped from SmartRecruiters and is the name the loader is registered under.
+const Source = "smartrecruiters"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public SmartRecruiters endpoints; use New to point it elsewhere.
+type Loader struct {
+	apiURL  string
+	jobsURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithAPIURL sets the base URL of the posting API, DefaultAPIURL by default.
+func WithAPIURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.apiURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithJobsURL sets the base URL of the hosted job pages, DefaultJobsURL by default.
+func WithJobsURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.jobsURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from SmartRecruiters.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from SmartRecruiters, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from SmartRecruiters, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from SmartRecruiters given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.apiURL == "" {
+		l.apiURL = DefaultAPIURL
+	}
+
+	if l.jobsURL == "" {
+		l.jobsURL = DefaultJobsURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultAPIURL is the base URL of the public SmartRecruiters posting API.
+	DefaultAPIURL = "https://api.smartrecruiters.com"
+	// DefaultJobsURL is the base URL of the hosted SmartRecruiters job pages.
+	DefaultJobsURL = "https://jobs.smartrecruiters.com"
+
+	smartRecruitersCompanyURL = "%s/v1/companies/%s/postings?offset=%d&limit=%d"
+	smartRecruitersJobURL     = "%s/v1/companies/%s/postings/%s"
+	smartRecruitersJobPageURL = "%s/%s/%s"
+
+	// pageSize is the most postings the list endpoint returns at once.
+	pageSize = 100
+	// maxPages bounds the listing in case the API keeps returning postings.
+	maxPages = 100
+)
+
+// ScrapeCompany scrapes all jobs for a given company from SmartRecruiters.
+func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	result, err := ScrapeCompanyResult(ctx, companyName)
+
+	return result.Jobs, err
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from SmartRecruiters, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
+
+// StreamCompany scrapes all jobs for a given company from SmartRecruiters, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "smartrecruiters"), slog.String("company_name", companyName))
+
+		jobIDs, listErrs, err := listPostings(ctx, companyName)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for _, err := range listErrs {
+			if !yield(nil, err) {
+				return
+			}
+		}
+
+		jobs := helpers.FetchEach(ctx, jobIDs, func(ctx context.Context, jobID string) (*models.Job, error) {
+			job, err := ScrapeJob(ctx, companyName, jobID)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to parse job", slog.String("ats", "smartrecruiters"), slog.String("company_name", companyName), slog.String("job_id", jobID), slog.Any("error", err))
+				return nil, &models.JobError{JobID: jobID, Stage: models.StageDetail, Err: err}
+			}
+
+			return job, nil
+		})
+
+		for job, err := range jobs {
+			if err != nil && ctx.Err() != nil {
+				break
+			}
+
+			if !yield(job, err) {
+				return
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			yield(nil, fmt.Errorf("failed to fetch jobs for company %s: %w", companyName, err))
+		}
+	}
+}
+
+// listPostings pages through the company's postings and returns the ID of each, stopping
+// once the reported total has been read or, without a total, once a page comes back empty.
+func listPostings(ctx context.Context, companyName string) ([]string, []error, error) {
+	jobIDs := make([]string, 0)
+	listErrs := make([]error, 0)
+
+	// total stays negative until a page reports one
+	offset, total := 0, -1
+
+	for range maxPages {
+		companyURL := fmt.Sprintf(smartRecruitersCompanyURL, endpoints(ctx).apiURL, companyName, offset, pageSize)
+
+		body, err := helpers.GetJSON(ctx, companyURL, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch jobs for company %s: %w", companyName, helpers.WrapNotFound(err, models.ErrBoardNotFound))
+		}
+
+		postings := 0
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			postings++
+
+			id, err := jsonparser.GetString(value, "id")
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to get posting ID", slog.String("ats", "smartrecruiters"), slog.String("company_name", companyName), slog.Any("error", err))
+				listErrs = append(listErrs, &models.JobError{Stage: models.StageList, Err: fmt.Errorf("failed to get posting ID: %w", err)})
+
+				return
+			}
+
+			jobIDs = append(jobIDs, id)
+		}, "content")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse jobs for company %s: %w", companyName, err)
+		}
+
+		pageTotal, err := jsonparser.GetInt(body, "totalFound")
+		if err == nil {
+			total = int(pageTotal)
+		}
+
+		offset += postings
+		if postings == 0 || (total >= 0 && offset >= total) {
+			break
+		}
+	}
+
+	return jobIDs, listErrs, nil
+}
+
+// ScrapeJob scrapes an individual job from SmartRecruiters given the company name and job ID.
+func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
+	slog.DebugContext(ctx, "Scraping job", slog.String("ats", "smartrecruiters"), slog.String("company_name", companyName), slog.String("job_id", jobID))
+	url := fmt.Sprintf(smartRecruitersJobURL, endpoints(ctx).apiURL, companyName, jobID)
+
+	body, err := helpers.GetJSON(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch job %s for company %s: %w", jobID, companyName, helpers.WrapNotFound(err, models.ErrJobGone))
+	}
+
+	job, err := parseSmartRecruitersJob(ctx, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse job %s for company %s: %w", jobID, companyName, err)
+	}
+
+	if job.URL == "" {
+		// https://jobs.smartrecruiters.com/Visa/744000012345678
+		job.URL = fmt.Sprintf(smartRecruitersJobPageURL, endpoints(ctx).jobsURL, companyName, job.SourceID)
+	}
+
+	return job, nil
+}
+
+func parseSmartRecruitersJob(ctx context.Context, data []byte) (*models.Job, error) {
+	job := models.NewJob(Source, data)
+
+	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, dataType jsonparser.ValueType, _ int) error {
+		switch string(key) {
+		case "id":
+			job.SourceID = string(value)
+		case "name":
+			job.Title = string(value)
+		case "postingUrl":
+			job.URL = string(value)
+		case "releasedDate":
+			job.ProcessDatePosted(ctx, value)
+		case "company":
+			name, err := jsonparser.GetString(value, "name")
+			if err == nil {
+				job.Company.Name = name
+			}
+		case "location":
+			parseLocation(job, value)
+		case "department":
+			department, err := jsonparser.GetString(value, "label")
+			if err == nil {
+				job.Department = models.ParseDepartment(department)
+				job.DepartmentRaw = department
+			}
+		case "typeOfEmployment":
+			employmentType, err := jsonparser.GetString(value, "label")
+			if err == nil {
+				job.EmploymentType = models.ParseEmploymentType(employmentType)
+			}
+		case "experienceLevel", "function", "industry":
+			label, err := jsonparser.GetString(value, "label")
+			if err == nil {
+				job.AddMetadata(string(key), label)
+			}
+		case "jobAd":
+			description, err := parseJobAd(value)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to parse job ad", slog.String("ats", "smartrecruiters"), slog.Any("error", err))
+				return fmt.Errorf("error parsing job ad: %w", err)
+			}
+
+			job.Description = description
+		default:
+			if dataType == jsonparser.String || dataType == jsonparser.Number || dataType == jsonparser.Boolean {
+				job.AddMetadata(string(key), string(value))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SmartRecruiters job object: %w", err)
+	}
+
+	return job, nil
+}
+
+// parseLocation sets the job's location from a posting's location object, whose remote and
+// hybrid flags say where the work happens.
+func parseLocation(job *models.Job, value []byte) {
+	job.Location, _ = jsonparser.GetString(value, "fullLocation")
+	if job.Location == "" {
+		job.Location = models.ParseLocation(value).String()
+	}
+
+	remote, remoteErr := jsonparser.GetBoolean(value, "remote")
+	hybrid, _ := jsonparser.GetBoolean(value, "hybrid")
+
+	switch {
+	case remote:
+		job.LocationType = models.RemoteLocation
+		job.IsRemote = true
+	case hybrid:
+		job.LocationType = models.HybridLocation
+	case remoteErr == nil:
+		job.LocationType = models.OnsiteLocation
+	}
+}
+
+// jobAdSections are the sections of a job ad, in the order they are shown on the job page.
+var jobAdSections = []string{"companyDescription", "jobDescription", "qualifications", "additionalInformation"}
+
+// parseJobAd joins the sections of a job ad into a single HTML description, each under its
+// own heading.
+func parseJobAd(value []byte) (string, error) {
+	sections, _, _, err := jsonparser.Get(value, "sections")
+	if err != nil {
+		return "", fmt.Errorf("error getting sections: %w", err)
+	}
+
+	var description strings.Builder
+
+	for _, section := range jobAdSections {
+		text, err := jsonparser.GetString(sections, section, "text")
+		if err != nil || text == "" {
+			continue
+		}
+
+		title, err := jsonparser.GetString(sections, section, "title")
+		if err == nil && title != "" {
+			description.WriteString("<h3>" + html.EscapeString(title) + "</h3>")
+		}
+
+		description.WriteString(text)
+	}
+
+	return description.String(), nil
+}
@@ -0,0 +1,203 @@
+package smartrecruiters
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/h2non/gock"
+)
+
+//go:embed single_job.json
+var singleJob string
+
+//go:embed job_list.json
+var jobList string
+
+func Test_parseSmartRecruitersJob(t *testing.T) {
+	t.Parallel()
+
+	job, err := parseSmartRecruitersJob(context.Background(), []byte(singleJob))
+	if err != nil {
+		t.Fatalf("parseSmartRecruitersJob() error = %v", err)
+	}
+
+	if job.SourceID != "744000089031835" {
+		t.Errorf("parseSmartRecruitersJob() SourceID = %v, want %v", job.SourceID, "744000089031835")
+	}
+
+	if job.Title != "Senior Data Engineer" {
+		t.Errorf("parseSmartRecruitersJob() Title = %v, want %v", job.Title, "Senior Data Engineer")
+	}
+
+	if job.Department != models.SoftwareEngineering {
+		t.Errorf("parseSmartRecruitersJob() Department = %v, want %v", job.Department, models.SoftwareEngineering)
+	}
+
+	if job.EmploymentType != models.FullTime {
+		t.Errorf("parseSmartRecruitersJob() EmploymentType = %v, want %v", job.EmploymentType, models.FullTime)
+	}
+
+	if job.Location != "Austin, TX, United States" {
+		t.Errorf("parseSmartRecruitersJob() Location = %v, want %v", job.Location, "Austin, TX, United States")
+	}
+
+	if job.LocationType != models.HybridLocation || job.IsRemote {
+		t.Errorf("parseSmartRecruitersJob() LocationType = %v, IsRemote = %v, want hybrid", job.LocationType, job.IsRemote)
+	}
+
+	if want := time.Date(2026, 9, 28, 14, 2, 11, 417000000, time.UTC); !job.DatePosted.Equal(want) {
+		t.Errorf("parseSmartRecruitersJob() DatePosted = %v, want %v", job.DatePosted, want)
+	}
+
+	if job.Company.Name != "Acme Corporation" {
+		t.Errorf("parseSmartRecruitersJob() Company.Name = %v, want %v", job.Company.Name, "Acme Corporation")
+	}
+
+	if job.URL != "https://jobs.smartrecruiters.com/Acme/744000089031835-senior-data-engineer" {
+		t.Errorf("parseSmartRecruitersJob() URL = %v", job.URL)
+	}
+
+	if got := job.GetMetadata("experienceLevel"); len(got) != 1 || got[0] != "Mid-Senior Level" {
+		t.Errorf("parseSmartRecruitersJob() experienceLevel = %v, want [Mid-Senior Level]", got)
+	}
+
+	// the empty additional information section is left out
+	for _, want := range []string{"<h3>Company Description</h3><p>Acme", "<h3>Qualifications</h3><ul>"} {
+		if !strings.Contains(job.Description, want) {
+			t.Errorf("parseSmartRecruitersJob() Description = %v, want it to contain %v", job.Description, want)
+		}
+	}
+
+	if strings.Contains(job.Description, "Additional Information") {
+		t.Errorf("parseSmartRecruitersJob() Description = %v, want no empty sections", job.Description)
+	}
+}
+
+func Test_parseLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		location     string
+		want         models.LocationType
+		remote       bool
+		wantLocation string
+	}{
+		{location: `{"city": "Dublin", "country": "ie", "remote": true, "hybrid": false, "fullLocation": "Dublin, Ireland"}`, want: models.RemoteLocation, remote: true, wantLocation: "Dublin, Ireland"},
+		{location: `{"city": "Berlin", "country": "de", "remote": false, "hybrid": false}`, want: models.OnsiteLocation, wantLocation: "Berlin, de"},
+		{location: `{"city": "Berlin", "country": "de"}`, want: models.UnknownLocationType, wantLocation: "Berlin, de"},
+	}
+
+	for _, tt := range tests {
+		job := models.NewJob(Source, nil)
+		parseLocation(job, []byte(tt.location))
+
+		if job.LocationType != tt.want || job.IsRemote != tt.remote {
+			t.Errorf("parseLocation(%s) = %v, remote %v, want %v, remote %v", tt.location, job.LocationType, job.IsRemote, tt.want, tt.remote)
+		}
+
+		if job.Location != tt.wantLocation {
+			t.Errorf("parseLocation(%s) Location = %v, want %v", tt.location, job.Location, tt.wantLocation)
+		}
+	}
+}
+
+func TestScrapeCompany(t *testing.T) {
+	t.Parallel()
+
+	defer gock.Off() // Flush pending mocks after test execution
+
+	gock.New("https://api.smartrecruiters.com").
+		Get("/v1/companies/Acme/postings").
+		MatchParam("offset", "^0$").
+		Reply(200).
+		JSON(jobList)
+
+	for _, id := range []string{"744000089031835", "744000088512207", "744000087044561"} {
+		gock.New("https://api.smartrecruiters.com").
+			Get("/v1/companies/Acme/postings/" + id).
+			Reply(200).
+			JSON(singleJob)
+	}
+
+	jobs, err := ScrapeCompany(context.Background(), "Acme")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 3 {
+		t.Errorf("ScrapeCompany() len(jobs) = %v, want 3", len(jobs))
+	}
+
+	// the second page picks up where the first left off
+	gock.New("https://api.smartrecruiters.com").
+		Get("/v1/companies/Paged/postings").
+		MatchParam("offset", "^0$").
+		Reply(200).
+		JSON(`{"offset": 0, "limit": 100, "totalFound": 2, "content": [{"id": "1"}]}`)
+
+	gock.New("https://api.smartrecruiters.com").
+		Get("/v1/companies/Paged/postings").
+		MatchParam("offset", "^1$").
+		Reply(200).
+		JSON(`{"offset": 1, "limit": 100, "totalFound": 2, "content": [{"id": "2"}]}`)
+
+	for _, id := range []string{"1", "2"} {
+		gock.New("https://api.smartrecruiters.com").
+			Get("/v1/companies/Paged/postings/" + id).
+			Reply(200).
+			JSON(singleJob)
+	}
+
+	jobs, err = ScrapeCompany(context.Background(), "Paged")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 2 {
+		t.Errorf("ScrapeCompany() len(jobs) = %v, want 2", len(jobs))
+	}
+
+	// without a total the listing carries on until a page comes back empty
+	for offset, body := range []string{
+		`{"offset": 0, "limit": 100, "content": [{"id": "1"}]}`,
+		`{"offset": 1, "limit": 100, "content": [{"id": "2"}]}`,
+		`{"offset": 2, "limit": 100, "content": []}`,
+	} {
+		gock.New("https://api.smartrecruiters.com").
+			Get("/v1/companies/Untotalled/postings").
+			MatchParam("offset", fmt.Sprintf("^%d$", offset)).
+			Reply(200).
+			JSON(body)
+	}
+
+	for _, id := range []string{"1", "2"} {
+		gock.New("https://api.smartrecruiters.com").
+			Get("/v1/companies/Untotalled/postings/" + id).
+			Reply(200).
+			JSON(singleJob)
+	}
+
+	jobs, err = ScrapeCompany(context.Background(), "Untotalled")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 2 {
+		t.Errorf("ScrapeCompany() without a total len(jobs) = %v, want 2", len(jobs))
+	}
+
+	gock.New("https://api.smartrecruiters.com").
+		Get("/v1/companies/Acme/postings/999").
+		Reply(404)
+
+	_, err = ScrapeJob(context.Background(), "Acme", "999")
+	if !errors.Is(err, models.ErrJobGone) {
+		t.Errorf("ScrapeJob() error = %v, want %v", err, models.ErrJobGone)
+	}
+}
@@ -0,0 +1,728 @@
+// Package teamtailor provides functions to scrape job postings from Teamtailor careers sites.
+//
+// Teamtailor's JSON:API needs a company's public API key, given with WithAPIKey. With one,
+// the company it was given for is read from the API along with the departments, locations
+// and roles of its jobs. Any other company, or the same one when the API refuses the key, is
+// read from the careers site's RSS feed instead, and a single job from the LD+JSON on its
+// page.
+package teamtailor
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+	"github.com/buger/jsonparser"
+)
+
+// Source identifies jobs scraped from Teamtailor and is the name the loader is registered under.
+const Source = "teamtailor"
+
+// ErrInvalidJobLink is returned for a job in the RSS feed whose link has no job ID.
+var ErrInvalidJobLink = errors.New("invalid Teamtailor job link")
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Teamtailor careers sites; use New to point it elsewhere
+// or to give it an API key.
+type Loader struct {
+	apiURL     string
+	companyURL string
+	apiKey     string
+	apiCompany string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithAPIURL sets the base URL of the Teamtailor API, DefaultAPIURL by default.
+func WithAPIURL(apiURL string) Option {
+	return func(l *Loader) {
+		l.apiURL = strings.TrimSuffix(apiURL, "/")
+	}
+}
+
+// WithCompanyURL sets the format of a company's careers site URL, with %s standing for the
+// company name, DefaultCompanyURL by default.
+func WithCompanyURL(format string) Option {
+	return func(l *Loader) {
+		l.companyURL = strings.TrimSuffix(format, "/")
+	}
+}
+
+// WithAPIKey sets the public API key the named company's jobs are read from the API with.
+// The API only ever returns the jobs of the company a key belongs to, so other companies are
+// still read from their careers sites.
+func WithAPIKey(companyName, key string) Option {
+	return func(l *Loader) {
+		l.apiCompany = companyName
+		l.apiKey = key
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Teamtailor.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Teamtailor, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Teamtailor, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Teamtailor given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.apiURL == "" {
+		l.apiURL = DefaultAPIURL
+	}
+
+	if l.companyURL == "" {
+		l.companyURL = DefaultCompanyURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultAPIURL is the base URL of the Teamtailor API.
+	DefaultAPIURL = "https://api.teamtailor.com/v1"
+	// DefaultCompanyURL is the format of a company's careers site URL, with %s standing for
+	// the company name.
+	DefaultCompanyURL = "https://%s.teamtailor.com"
+
+	// apiVersion is the version of the Teamtailor API the loader speaks.
+	apiVersion = "20240904"
+	// include names the relationships the API embeds in a job response.
+	include = "department,locations,role"
+
+	teamtailorJobsURL    = "%s/jobs?include=" + include + "&page%%5Bsize%%5D=30"
+	teamtailorJobURL     = "%s/jobs/%s?include=" + include
+	teamtailorCompanyURL = "%s/company"
+	teamtailorFeedURL    = "%s/jobs.rss"
+	teamtailorJobPageURL = "%s/jobs/%s"
+
+	// maxPages caps how many pages of jobs are read, in case the next links never run out.
+	maxPages = 100
+)
+
+// rssFeed is a careers site's RSS feed of open jobs.
+type rssFeed struct {
+	Channel struct {
+		// Title is the company's name.
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// rssItem is a single job in a careers site's RSS feed.
+type rssItem struct {
+	Title        string        `xml:"title"`
+	Description  string        `xml:"description"`
+	Link         string        `xml:"link"`
+	PubDate      string        `xml:"pubDate"`
+	RemoteStatus string        `xml:"remoteStatus"`
+	Department   string        `xml:"department"`
+	Role         string        `xml:"role"`
+	Locations    []rssLocation `xml:"locations>location"`
+
+	// Raw is the item's XML, kept as the job's source data.
+	Raw []byte `xml:",innerxml"`
+}
+
+// rssLocation is one of the locations of a job in a careers site's RSS feed.
+type rssLocation struct {
+	Name    string `xml:"name"`
+	City    string `xml:"city"`
+	Country string `xml:"country"`
+}
+
+// companyURL returns the careers site URL of the named company.
+func companyURL(ctx context.Context, companyName string) string {
+	return fmt.Sprintf(endpoints(ctx).companyURL, companyName)
+}
+
+// hasAPIKey reports whether the loader carried by ctx has an API key for the named company.
+func hasAPIKey(ctx context.Context, companyName string) bool {
+	l := endpoints(ctx)
+
+	return l.apiKey != "" && strings.EqualFold(l.apiCompany, companyName)
+}
+
+// apiHeaders returns the headers an API request is made with.
+func apiHeaders(ctx context.Context) map[string]string {
+	return map[string]string{
+		"Accept":        "application/vnd.api+json",
+		"Authorization": "Token token=" + endpoints(ctx).apiKey,
+		"X-Api-Version": apiVersion,
+	}
+}
+
+// unavailable reports whether err means the API can't be used, so the careers site should be
+// read instead.
+func unavailable(err error) bool {
+	var httpErr *helpers.HTTPError
+
+	return errors.As(err, &httpErr) && httpErr.Blocked()
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Teamtailor.
+func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	result, err := ScrapeCompanyResult(ctx, companyName)
+
+	return result.Jobs, err
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Teamtailor, reporting the
+// jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
+
+// StreamCompany scrapes all jobs for a given company from Teamtailor, yielding each job as it
+// is parsed. Jobs that fail are yielded as a *models.JobError and the stream carries on; any
+// other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "teamtailor"), slog.String("company_name", companyName))
+
+		if hasAPIKey(ctx, companyName) && streamAPI(ctx, companyName, yield) {
+			return
+		}
+
+		streamFeed(ctx, companyName, yield)
+	}
+}
+
+// streamAPI yields the company's jobs from the API. It reports false, having yielded
+// nothing, when the API refuses the key.
+func streamAPI(ctx context.Context, companyName string, yield func(*models.Job, error) bool) bool {
+	ctx = companyinfo.Ensure(ctx)
+
+	// The URL is like https://api.teamtailor.com/v1/jobs?include=department,locations,role
+	pageURL := fmt.Sprintf(teamtailorJobsURL, endpoints(ctx).apiURL)
+
+	for page := 0; pageURL != "" && page < maxPages; page++ {
+		body, err := helpers.GetJSON(ctx, pageURL, apiHeaders(ctx))
+		if err != nil {
+			if page == 0 && unavailable(err) {
+				slog.WarnContext(ctx, "Teamtailor API refused the key, reading the RSS feed instead", slog.String("url", pageURL), slog.Any("error", err))
+				return false
+			}
+
+			slog.ErrorContext(ctx, "Error getting JSON from Teamtailor jobs endpoint", slog.String("url", pageURL), slog.Any("error", err))
+			yield(nil, fmt.Errorf("error getting JSON from Teamtailor jobs endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
+
+			return true
+		}
+
+		included := parseIncluded(body)
+		stopped := false
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			if stopped {
+				return
+			}
+
+			job, jerr := parseAPIJob(ctx, value, included)
+			if jerr != nil {
+				slog.ErrorContext(ctx, "Error parsing Teamtailor job from jobs array", slog.Any("error", jerr))
+				jobID, _ := jsonparser.GetString(value, "id")
+				stopped = !yield(nil, &models.JobError{JobID: jobID, Stage: models.StageList, Err: jerr})
+
+				return
+			}
+
+			company, cerr := companyInfo(ctx, companyName)
+			if cerr != nil {
+				slog.ErrorContext(ctx, "Error scraping company info for Teamtailor job", slog.String("company_name", companyName), slog.Any("error", cerr))
+
+				if !yield(nil, &models.JobError{JobID: job.SourceID, Stage: models.StageCompanyInfo, Err: cerr}) {
+					stopped = true
+					return
+				}
+			} else {
+				job.Company = company
+			}
+
+			stopped = !yield(job, nil)
+		}, "data")
+		if stopped {
+			return true
+		}
+
+		if err != nil {
+			slog.ErrorContext(ctx, "Error parsing data array from Teamtailor jobs endpoint", slog.Any("error", err))
+			yield(nil, fmt.Errorf("error parsing data array: %w", err))
+
+			return true
+		}
+
+		// the last page has no next link
+		pageURL, _ = jsonparser.GetString(body, "links", "next")
+	}
+
+	return true
+}
+
+// streamFeed yields the company's jobs from its careers site's RSS feed.
+func streamFeed(ctx context.Context, companyName string, yield func(*models.Job, error) bool) {
+	// The URL is like https://{companyName}.teamtailor.com/jobs.rss
+	feedURL := fmt.Sprintf(teamtailorFeedURL, companyURL(ctx, companyName))
+
+	body, err := helpers.GetHTML(ctx, feedURL)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting Teamtailor RSS feed", slog.String("url", feedURL), slog.Any("error", err))
+		yield(nil, fmt.Errorf("error getting Teamtailor RSS feed: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound)))
+
+		return
+	}
+
+	var feed rssFeed
+
+	err = xml.Unmarshal(body, &feed)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing Teamtailor RSS feed", slog.String("url", feedURL), slog.Any("error", err))
+		yield(nil, fmt.Errorf("error parsing Teamtailor RSS feed: %w", err))
+
+		return
+	}
+
+	for _, item := range feed.Channel.Items {
+		job, err := parseFeedJob(ctx, item)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error parsing Teamtailor job from RSS feed", slog.String("link", item.Link), slog.Any("error", err))
+
+			if !yield(nil, &models.JobError{JobID: jobIDFromURL(item.Link), Stage: models.StageList, Err: err}) {
+				return
+			}
+
+			continue
+		}
+
+		job.Company.Name = feed.Channel.Title
+
+		if !yield(job, nil) {
+			return
+		}
+	}
+}
+
+// ScrapeJob scrapes an individual job from Teamtailor given the company name and job ID.
+func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
+	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "teamtailor"), slog.String("company_name", companyName), slog.String("job_id", jobID))
+
+	if hasAPIKey(ctx, companyName) {
+		job, err := scrapeAPIJob(ctx, companyName, jobID)
+		if !unavailable(err) {
+			return job, err
+		}
+
+		slog.WarnContext(ctx, "Teamtailor API refused the key, reading the job page instead", slog.String("job_id", jobID), slog.Any("error", err))
+	}
+
+	return scrapeJobPage(ctx, companyName, jobID)
+}
+
+// scrapeAPIJob scrapes an individual job from the API.
+func scrapeAPIJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	// The URL is like https://api.teamtailor.com/v1/jobs/{jobID}?include=department,locations,role
+	jobURL := fmt.Sprintf(teamtailorJobURL, endpoints(ctx).apiURL, url.PathEscape(jobID))
+
+	body, err := helpers.GetJSON(ctx, jobURL, apiHeaders(ctx))
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting JSON from Teamtailor job endpoint", slog.String("url", jobURL), slog.Any("error", err))
+		return nil, fmt.Errorf("error getting JSON from Teamtailor job endpoint: %w", helpers.WrapNotFound(err, models.ErrJobGone))
+	}
+
+	data, _, _, err := jsonparser.Get(body, "data")
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting data from Teamtailor job endpoint", slog.Any("error", err))
+		return nil, fmt.Errorf("error getting data from Teamtailor job endpoint: %w", err)
+	}
+
+	job, err := parseAPIJob(ctx, data, parseIncluded(body))
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing Teamtailor job from job endpoint", slog.Any("error", err))
+		return nil, fmt.Errorf("error parsing Teamtailor job from job endpoint: %w", err)
+	}
+
+	company, err := companyInfo(ctx, companyName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error scraping company info for Teamtailor job", slog.String("company_name", companyName), slog.Any("error", err))
+	} else {
+		job.Company = company
+	}
+
+	return job, nil
+}
+
+// scrapeJobPage scrapes an individual job from the LD+JSON on its careers site page.
+func scrapeJobPage(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	// The URL is like https://{companyName}.teamtailor.com/jobs/{jobID}
+	pageURL := fmt.Sprintf(teamtailorJobPageURL, companyURL(ctx, companyName), url.PathEscape(jobID))
+
+	ldjson, err := helpers.GetLDJSON(ctx, pageURL)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting LD+JSON from Teamtailor job page", slog.String("url", pageURL), slog.Any("error", err))
+		return nil, fmt.Errorf("error getting LD+JSON from Teamtailor job page: %w", helpers.WrapNotFound(err, models.ErrJobGone))
+	}
+
+	data, err := json.Marshal(ldjson)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding LD+JSON from Teamtailor job page: %w", err)
+	}
+
+	return parseJSONLDJob(ctx, jobID, pageURL, data), nil
+}
+
+// companyInfo returns the company information for companyName through the company info
+// cache carried by ctx, if any.
+func companyInfo(ctx context.Context, companyName string) (*models.Company, error) {
+	return companyinfo.Lookup(ctx, Source, companyName, scrapeCompanyInfo)
+}
+
+// scrapeCompanyInfo scrapes the company the API key belongs to.
+func scrapeCompanyInfo(ctx context.Context) (*models.Company, error) {
+	companyInfoURL := fmt.Sprintf(teamtailorCompanyURL, endpoints(ctx).apiURL)
+
+	body, err := helpers.GetJSON(ctx, companyInfoURL, apiHeaders(ctx))
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting JSON from Teamtailor company endpoint", slog.String("url", companyInfoURL), slog.Any("error", err))
+		return nil, fmt.Errorf("error getting JSON from Teamtailor company endpoint: %w", err)
+	}
+
+	company := models.NewCompany()
+
+	name, err := jsonparser.GetString(body, "data", "attributes", "name")
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing name from Teamtailor company endpoint", slog.Any("error", err))
+		return nil, fmt.Errorf("error parsing company name: %w", err)
+	}
+
+	company.Name = name
+
+	website, err := jsonparser.GetString(body, "data", "attributes", "website")
+	if err == nil && website != "" {
+		homepage, err := url.Parse(website)
+		if err == nil {
+			company.Homepage = *homepage
+		} else {
+			slog.ErrorContext(ctx, "Error parsing company website from Teamtailor company endpoint", slog.String("url", website), slog.Any("error", err))
+		}
+	}
+
+	return company, nil
+}
+
+// parseIncluded indexes the resources embedded in an API response by type and ID, e.g.
+// departments/12, keeping their attributes.
+func parseIncluded(body []byte) map[string][]byte {
+	included := make(map[string][]byte)
+
+	_, _ = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+		id, _ := jsonparser.GetString(value, "id")
+		kind, _ := jsonparser.GetString(value, "type")
+
+		attributes, _, _, err := jsonparser.Get(value, "attributes")
+		if err == nil {
+			included[kind+"/"+id] = attributes
+		}
+	}, "included")
+
+	return included
+}
+
+// related returns the attributes of the included resources a job's relationship points at.
+// A relationship's data is a single resource identifier or an array of them.
+func related(data []byte, included map[string][]byte, relationship string) [][]byte {
+	value, dataType, _, err := jsonparser.Get(data, "relationships", relationship, "data")
+	if err != nil {
+		return nil
+	}
+
+	var attributes [][]byte
+
+	add := func(identifier []byte) {
+		id, _ := jsonparser.GetString(identifier, "id")
+		kind, _ := jsonparser.GetString(identifier, "type")
+
+		if attrs, ok := included[kind+"/"+id]; ok {
+			attributes = append(attributes, attrs)
+		}
+	}
+
+	switch dataType {
+	case jsonparser.Object:
+		add(value)
+	case jsonparser.Array:
+		_, _ = jsonparser.ArrayEach(value, func(identifier []byte, _ jsonparser.ValueType, _ int, _ error) {
+			add(identifier)
+		})
+	default:
+		// a null relationship has nothing to include
+	}
+
+	return attributes
+}
+
+func parseAPIJob(ctx context.Context, data []byte, included map[string][]byte) (*models.Job, error) {
+	job := models.NewJob(Source, data)
+
+	id, err := jsonparser.GetString(data, "id")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing job ID: %w", err)
+	}
+
+	job.SourceID = id
+
+	var minSalary, maxSalary []byte
+
+	err = jsonparser.ObjectEach(data, func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
+		switch string(key) {
+		case "title":
+			job.Title = string(value)
+		case "body":
+			job.Description = string(value)
+		case "remote-status":
+			job.LocationType = parseRemoteStatus(string(value))
+			job.IsRemote = job.LocationType == models.RemoteLocation
+			job.AddMetadata("remote-status", string(value))
+		case "employment-type":
+			job.EmploymentType = parseEmploymentType(string(value))
+			job.AddMetadata("employment-type", string(value))
+		case "employment-level":
+			job.AddMetadata("employment-level", string(value))
+		case "created-at":
+			job.ProcessDatePosted(ctx, value)
+		case "min-salary":
+			minSalary = value
+		case "max-salary":
+			maxSalary = value
+		case "currency":
+			job.CompensationUnit = string(value)
+		case "salary-time-unit":
+			job.AddMetadata("compensation_interval", string(value))
+		}
+
+		return nil
+	}, "attributes")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Teamtailor job attributes: %w", err)
+	}
+
+	job.MinCompensation, _ = strconv.ParseFloat(string(minSalary), 64)
+	job.MaxCompensation, _ = strconv.ParseFloat(string(maxSalary), 64)
+
+	job.URL, _ = jsonparser.GetString(data, "links", "careersite-job-url")
+
+	for _, department := range related(data, included, "department") {
+		name, _ := jsonparser.GetString(department, "name")
+		job.Department = models.ParseDepartment(name)
+		job.DepartmentRaw = name
+	}
+
+	for _, location := range related(data, included, "locations") {
+		name, _ := jsonparser.GetString(location, "name")
+		if name == "" {
+			name = models.ParseLocation(location).String()
+		}
+
+		if job.Location == "" {
+			job.Location = name
+		}
+
+		job.AddMetadata("locations", name)
+	}
+
+	for _, role := range related(data, included, "role") {
+		name, _ := jsonparser.GetString(role, "name")
+		job.AddMetadata("role", name)
+	}
+
+	return job, nil
+}
+
+func parseFeedJob(ctx context.Context, item rssItem) (*models.Job, error) {
+	job := models.NewJob(Source, item.Raw)
+
+	job.SourceID = jobIDFromURL(item.Link)
+	if job.SourceID == "" {
+		return nil, fmt.Errorf("%w: %q, want a link to /jobs/<id>", ErrInvalidJobLink, item.Link)
+	}
+
+	job.Title = strings.TrimSpace(item.Title)
+	job.Description = strings.TrimSpace(item.Description)
+	job.URL = strings.TrimSpace(item.Link)
+	job.LocationType = parseRemoteStatus(item.RemoteStatus)
+	job.IsRemote = job.LocationType == models.RemoteLocation
+
+	if item.Department != "" {
+		job.Department = models.ParseDepartment(item.Department)
+		job.DepartmentRaw = item.Department
+	}
+
+	for _, location := range item.Locations {
+		name := location.Name
+		if name == "" {
+			name = models.Location{City: location.City, Country: location.Country}.String()
+		}
+
+		if job.Location == "" {
+			job.Location = name
+		}
+
+		job.AddMetadata("locations", name)
+	}
+
+	job.AddMetadata("remote-status", item.RemoteStatus)
+	job.AddMetadata("role", item.Role)
+
+	pubDate, err := time.Parse(time.RFC1123Z, strings.TrimSpace(item.PubDate))
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing pubDate", slog.String("pub_date", item.PubDate), slog.Any("error", err))
+		// we continue even if there's an error here
+	} else {
+		job.DatePosted = pubDate.UTC()
+	}
+
+	return job, nil
+}
+
+func parseJSONLDJob(ctx context.Context, jobID, pageURL string, data []byte) *models.Job {
+	job := models.NewJob(Source, data)
+
+	job.SourceID = jobID
+	job.URL = pageURL
+	job.Title, _ = jsonparser.GetString(data, "title")
+	job.Description, _ = jsonparser.GetString(data, "description")
+	job.Company.Name, _ = jsonparser.GetString(data, "hiringOrganization", "name")
+
+	datePosted, _, _, err := jsonparser.Get(data, "datePosted")
+	if err == nil {
+		job.ProcessDatePosted(ctx, datePosted)
+	}
+
+	// employmentType may be a single value or a list of them; the first one wins
+	employmentTypes, dataType, _, _ := jsonparser.Get(data, "employmentType")
+	if dataType == jsonparser.Array {
+		employmentTypes, _, _, _ = jsonparser.Get(employmentTypes, "[0]")
+	}
+
+	job.EmploymentType = parseEmploymentType(string(employmentTypes))
+
+	jobLocationType, _ := jsonparser.GetString(data, "jobLocationType")
+	if models.ParseLocationType(jobLocationType) == models.RemoteLocation {
+		job.LocationType = models.RemoteLocation
+		job.IsRemote = true
+	}
+
+	// jobLocation may be a single place or a list of them
+	locations, dataType, _, _ := jsonparser.Get(data, "jobLocation")
+	if dataType == jsonparser.Object {
+		locations = []byte("[" + string(locations) + "]")
+	}
+
+	_, _ = jsonparser.ArrayEach(locations, func(place []byte, _ jsonparser.ValueType, _ int, _ error) {
+		address, _, _, err := jsonparser.Get(place, "address")
+		if err != nil {
+			return
+		}
+
+		name := models.ParseLocation(address).String()
+		if job.Location == "" {
+			job.Location = name
+		}
+
+		job.AddMetadata("locations", name)
+	})
+
+	return job
+}
+
+// parseRemoteStatus maps a job's remote status, one of none, hybrid, temporary or fully, to a
+// LocationType. A temporarily remote job is expected back in the office, so it counts as
+// hybrid.
+func parseRemoteStatus(status string) models.LocationType {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "fully":
+		return models.RemoteLocation
+	case "hybrid", "temporary":
+		return models.HybridLocation
+	case "none":
+		return models.OnsiteLocation
+	default:
+		return models.UnknownLocationType
+	}
+}
+
+// parseEmploymentType maps an employment type, such as fulltime from the API or FULL_TIME
+// from LD+JSON, to an EmploymentType.
+func parseEmploymentType(employmentType string) models.EmploymentType {
+	switch strings.ToLower(employmentType) {
+	case "freelance":
+		return models.Contract
+	default:
+		return models.ParseEmploymentType(employmentType)
+	}
+}
+
+// jobIDFromURL returns the job ID from a job's careers site URL, such as 123456 from
+// https://acme.teamtailor.com/jobs/123456-senior-engineer.
+func jobIDFromURL(jobURL string) string {
+	u, err := url.Parse(strings.TrimSpace(jobURL))
+	if err != nil {
+		return ""
+	}
+
+	_, slug, ok := strings.Cut(u.Path, "/jobs/")
+	if !ok {
+		return ""
+	}
+
+	id, _, _ := strings.Cut(strings.Trim(slug, "/"), "-")
+
+	return id
+}
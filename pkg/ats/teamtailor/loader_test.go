@@ -0,0 +1,303 @@
+package teamtailor
+
+import (
+	"context"
+	_ "embed"
+	"encoding/xml"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/buger/jsonparser"
+	"github.com/h2non/gock"
+)
+
+//go:embed job_list.json
+var jobList string
+
+//go:embed single_job.json
+var singleJob string
+
+//go:embed job_list.rss
+var jobFeed string
+
+//go:embed job_page.html
+var jobPage string
+
+func Test_parseAPIJob(t *testing.T) {
+	t.Parallel()
+
+	data, _, _, err := jsonparser.Get([]byte(jobList), "data", "[0]")
+	if err != nil {
+		t.Fatalf("jsonparser.Get() error = %v", err)
+	}
+
+	job, err := parseAPIJob(context.Background(), data, parseIncluded([]byte(jobList)))
+	if err != nil {
+		t.Fatalf("parseAPIJob() error = %v", err)
+	}
+
+	if job.SourceID != "4211078" {
+		t.Errorf("parseAPIJob() SourceID = %v, want %v", job.SourceID, "4211078")
+	}
+
+	if job.Title != "Senior Platform Engineer" {
+		t.Errorf("parseAPIJob() Title = %v, want %v", job.Title, "Senior Platform Engineer")
+	}
+
+	if job.URL != "https://acme.teamtailor.com/jobs/4211078-senior-platform-engineer" {
+		t.Errorf("parseAPIJob() URL = %v", job.URL)
+	}
+
+	if job.LocationType != models.RemoteLocation || !job.IsRemote {
+		t.Errorf("parseAPIJob() LocationType = %v, IsRemote = %v, want remote", job.LocationType, job.IsRemote)
+	}
+
+	if job.EmploymentType != models.FullTime {
+		t.Errorf("parseAPIJob() EmploymentType = %v, want %v", job.EmploymentType, models.FullTime)
+	}
+
+	if job.Department != models.SoftwareEngineering || job.DepartmentRaw != "Engineering" {
+		t.Errorf("parseAPIJob() Department = %v (%v), want %v", job.Department, job.DepartmentRaw, models.SoftwareEngineering)
+	}
+
+	if job.Location != "Stockholm HQ" {
+		t.Errorf("parseAPIJob() Location = %v, want %v", job.Location, "Stockholm HQ")
+	}
+
+	// metadata values come back in no particular order
+	if got := job.GetMetadata("locations"); len(got) != 2 || !slices.Contains(got, "Stockholm HQ") || !slices.Contains(got, "Berlin Office") {
+		t.Errorf("parseAPIJob() locations = %v, want Stockholm HQ and Berlin Office", got)
+	}
+
+	if got := job.GetMetadata("role"); len(got) != 1 || got[0] != "Platform Engineer" {
+		t.Errorf("parseAPIJob() role = %v, want [Platform Engineer]", got)
+	}
+
+	if job.MinCompensation != 70000 || job.MaxCompensation != 85000 || job.CompensationUnit != "EUR" {
+		t.Errorf("parseAPIJob() compensation = %v-%v %v, want 70000-85000 EUR", job.MinCompensation, job.MaxCompensation, job.CompensationUnit)
+	}
+
+	if want := time.Date(2026, 9, 24, 7, 12, 44, 512000000, time.UTC); !job.DatePosted.Equal(want) {
+		t.Errorf("parseAPIJob() DatePosted = %v, want %v", job.DatePosted, want)
+	}
+}
+
+func Test_parseFeedJob(t *testing.T) {
+	t.Parallel()
+
+	var feed rssFeed
+
+	err := xml.Unmarshal([]byte(jobFeed), &feed)
+	if err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+
+	if feed.Channel.Title != "Acme" || len(feed.Channel.Items) != 3 {
+		t.Fatalf("xml.Unmarshal() = %v with %d items, want Acme with 3", feed.Channel.Title, len(feed.Channel.Items))
+	}
+
+	job, err := parseFeedJob(context.Background(), feed.Channel.Items[0])
+	if err != nil {
+		t.Fatalf("parseFeedJob() error = %v", err)
+	}
+
+	if job.SourceID != "4211078" {
+		t.Errorf("parseFeedJob() SourceID = %v, want %v", job.SourceID, "4211078")
+	}
+
+	if job.LocationType != models.RemoteLocation || !job.IsRemote {
+		t.Errorf("parseFeedJob() LocationType = %v, IsRemote = %v, want remote", job.LocationType, job.IsRemote)
+	}
+
+	if job.DepartmentRaw != "Engineering" || job.Location != "Stockholm HQ" {
+		t.Errorf("parseFeedJob() DepartmentRaw = %v, Location = %v, want Engineering in Stockholm HQ", job.DepartmentRaw, job.Location)
+	}
+
+	if want := time.Date(2026, 9, 24, 7, 12, 44, 0, time.UTC); !job.DatePosted.Equal(want) {
+		t.Errorf("parseFeedJob() DatePosted = %v, want %v", job.DatePosted, want)
+	}
+
+	// a location without a name is named after its city and country
+	job, err = parseFeedJob(context.Background(), feed.Channel.Items[2])
+	if err != nil {
+		t.Fatalf("parseFeedJob() error = %v", err)
+	}
+
+	if job.Location != "Gothenburg, Sweden" || job.LocationType != models.OnsiteLocation {
+		t.Errorf("parseFeedJob() Location = %v, LocationType = %v, want Gothenburg, Sweden on site", job.Location, job.LocationType)
+	}
+
+	_, err = parseFeedJob(context.Background(), rssItem{Link: "https://acme.teamtailor.com/departments"})
+	if !errors.Is(err, ErrInvalidJobLink) {
+		t.Errorf("parseFeedJob() error = %v, want %v", err, ErrInvalidJobLink)
+	}
+}
+
+func Test_parseRemoteStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]models.LocationType{
+		"fully":     models.RemoteLocation,
+		"hybrid":    models.HybridLocation,
+		"temporary": models.HybridLocation,
+		"none":      models.OnsiteLocation,
+		"":          models.UnknownLocationType,
+	}
+
+	for status, want := range tests {
+		if got := parseRemoteStatus(status); got != want {
+			t.Errorf("parseRemoteStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestScrapeCompany(t *testing.T) {
+	t.Parallel()
+
+	defer gock.Off() // Flush pending mocks after test execution
+
+	loader := New(WithAPIKey("acme", "public-key"))
+
+	gock.New("https://api.teamtailor.com").
+		Get("/v1/jobs").
+		MatchHeader("Authorization", "^Token token=public-key$").
+		MatchParam("include", "department,locations,role").
+		Reply(200).
+		JSON(jobList)
+
+	gock.New("https://api.teamtailor.com").
+		Get("/v1/company").
+		Reply(200).
+		JSON(`{"data": {"id": "acme", "type": "companies", "attributes": {"name": "Acme", "website": "https://acme.example.com"}}}`)
+
+	jobs, err := loader.ScrapeCompany(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 3 {
+		t.Fatalf("ScrapeCompany() len(jobs) = %v, want 3", len(jobs))
+	}
+
+	// the company is looked up once for the whole scrape
+	for _, job := range jobs {
+		if job.Company.Name != "Acme" {
+			t.Errorf("ScrapeCompany() Company.Name = %v, want Acme", job.Company.Name)
+		}
+	}
+
+	if jobs[1].LocationType != models.HybridLocation || jobs[1].EmploymentType != models.PartTime || jobs[1].MinCompensation != 0 {
+		t.Errorf("ScrapeCompany() jobs[1] = %v, %v, %v, want an unpaid hybrid part-time job", jobs[1].LocationType, jobs[1].EmploymentType, jobs[1].MinCompensation)
+	}
+
+	if jobs[2].Location != "Gothenburg, Sweden" || jobs[2].DepartmentRaw != "" {
+		t.Errorf("ScrapeCompany() jobs[2] = %v in %v, want no department in Gothenburg, Sweden", jobs[2].DepartmentRaw, jobs[2].Location)
+	}
+
+	// a refused key falls back to the RSS feed
+	gock.New("https://api.teamtailor.com").
+		Get("/v1/jobs").
+		Reply(401)
+
+	gock.New("https://acme.teamtailor.com").
+		Get("/jobs.rss").
+		Reply(200).
+		Type("application/rss+xml").
+		BodyString(jobFeed)
+
+	jobs, err = loader.ScrapeCompany(context.Background(), "acme")
+	if err != nil || len(jobs) != 3 || jobs[0].Company.Name != "Acme" {
+		t.Errorf("ScrapeCompany() after a refused key = %v, %v, want 3 jobs from the feed", jobs, err)
+	}
+
+	// the key only belongs to acme, so any other company is read from its feed
+	gock.New("https://globex.teamtailor.com").
+		Get("/jobs.rss").
+		Reply(200).
+		Type("application/rss+xml").
+		BodyString(jobFeed)
+
+	jobs, err = loader.ScrapeCompany(context.Background(), "globex")
+	if err != nil || len(jobs) != 3 {
+		t.Errorf("ScrapeCompany() for another company = %v, %v, want 3 jobs from its feed", jobs, err)
+	}
+
+	// jobs whose company can't be looked up are kept, alongside an error for each
+	gock.New("https://api.teamtailor.com").
+		Get("/v1/jobs").
+		Reply(200).
+		JSON(jobList)
+
+	gock.New("https://api.teamtailor.com").
+		Get("/v1/company").
+		Reply(404)
+
+	result, err := loader.ScrapeCompanyResult(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("ScrapeCompanyResult() error = %v", err)
+	}
+
+	if len(result.Jobs) != 3 || len(result.Errors) != 3 {
+		t.Fatalf("ScrapeCompanyResult() = %d jobs, %d errors, want 3 jobs, 3 errors", len(result.Jobs), len(result.Errors))
+	}
+
+	if jobErr := result.Errors[0]; jobErr.JobID != "4211078" || jobErr.Stage != models.StageCompanyInfo {
+		t.Errorf("ScrapeCompanyResult() error = %v, want job 4211078 at the company info stage", jobErr)
+	}
+
+	// the zero value reads the feed directly
+	gock.New("https://missing.teamtailor.com").
+		Get("/jobs.rss").
+		Reply(404)
+
+	_, err = ScrapeCompany(context.Background(), "missing")
+	if !errors.Is(err, models.ErrBoardNotFound) {
+		t.Errorf("ScrapeCompany() error = %v, want %v", err, models.ErrBoardNotFound)
+	}
+
+	gock.New("https://api.teamtailor.com").
+		Get("/v1/jobs/4211078").
+		Reply(200).
+		JSON(singleJob)
+
+	gock.New("https://api.teamtailor.com").
+		Get("/v1/company").
+		Reply(200).
+		JSON(`{"data": {"id": "acme", "type": "companies", "attributes": {"name": "Acme"}}}`)
+
+	job, err := loader.ScrapeJob(context.Background(), "acme", "4211078")
+	if err != nil || job.Title != "Senior Platform Engineer" || job.DepartmentRaw != "Engineering" {
+		t.Errorf("ScrapeJob() = %v, %v, want the platform engineer job", job, err)
+	}
+
+	gock.New("https://acme.teamtailor.com").
+		Get("/jobs/4211078").
+		Reply(200).
+		Type("text/html").
+		BodyString(jobPage)
+
+	job, err = ScrapeJob(context.Background(), "acme", "4211078")
+	if err != nil {
+		t.Fatalf("ScrapeJob() error = %v", err)
+	}
+
+	if job.Title != "Senior Platform Engineer" || job.Company.Name != "Acme" || job.Location != "Stockholm, Sweden" {
+		t.Errorf("ScrapeJob() = %v at %v in %v, want the platform engineer job at Acme in Stockholm, Sweden", job.Title, job.Company.Name, job.Location)
+	}
+
+	if job.EmploymentType != models.FullTime || !job.IsRemote {
+		t.Errorf("ScrapeJob() EmploymentType = %v, IsRemote = %v, want a remote full-time job", job.EmploymentType, job.IsRemote)
+	}
+
+	gock.New("https://acme.teamtailor.com").
+		Get("/jobs/999").
+		Reply(404)
+
+	_, err = ScrapeJob(context.Background(), "acme", "999")
+	if !errors.Is(err, models.ErrJobGone) {
+		t.Errorf("ScrapeJob() error = %v, want %v", err, models.ErrJobGone)
+	}
+}
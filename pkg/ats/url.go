@@ -0,0 +1,253 @@
+package ats
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/ashby"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/bamboo"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/gem"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/greenhouse"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/lever"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/personio"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/recruitee"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/rippling"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/smartrecruiters"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/teamtailor"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/workable"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/workday"
+)
+
+// ErrUnsupportedURL is returned by ParseURL for URLs that aren't hosted by a supported ATS.
+var ErrUnsupportedURL = errors.New("URL is not a supported ATS job board or posting")
+
+// Target is the job board or job posting a URL points at.
+type Target struct {
+	// ATS is the name of the loader for the board, e.g. greenhouse.
+	ATS string `json:"ats"`
+	// Company is the board's company slug.
+	Company string `json:"company"`
+	// JobID is the posting's ID, or empty when the URL points at the whole board.
+	JobID string `json:"job_id,omitempty"`
+
+	// eu is set for Lever boards hosted in the EU, which are served by a different API.
+	eu bool
+}
+
+// Loader returns the loader to scrape the target with from the default registry, or one
+// configured for the target's region when the registered one can't reach it.
+func (t Target) Loader() (Loader, error) {
+	if t.eu {
+		return lever.New(lever.WithAPIURL(lever.EUAPIURL)), nil
+	}
+
+	return Get(t.ATS)
+}
+
+// ParseURL returns the ATS, company and optional job ID of a job board or job posting URL,
+// such as https://boards.greenhouse.io/acme/jobs/123 or https://jobs.lever.co/acme. URLs
+// without a scheme are assumed to be https.
+func ParseURL(rawURL string) (Target, error) {
+	rawURL = withScheme(rawURL)
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Target{}, fmt.Errorf("error parsing URL %s: %w", rawURL, err)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+
+	target, ok := parseTarget(host, segments, u.Query())
+	if !ok || target.Company == "" {
+		return Target{}, fmt.Errorf("%w: %s", ErrUnsupportedURL, rawURL)
+	}
+
+	return target, nil
+}
+
+// withScheme trims rawURL and gives it the https scheme if it has none, including
+// protocol-relative URLs such as //jobs.lever.co/acme.
+func withScheme(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+
+	switch {
+	case strings.HasPrefix(rawURL, "//"):
+		return "https:" + rawURL
+	case !strings.Contains(rawURL, "://"):
+		return "https://" + rawURL
+	default:
+		return rawURL
+	}
+}
+
+func parseTarget(host string, segments []string, query url.Values) (Target, bool) {
+	if company, ok := strings.CutSuffix(host, ".bamboohr.com"); ok && company != "" && !strings.Contains(company, ".") {
+		return parseBamboo(company, segments, query), true
+	}
+
+	for _, suffix := range []string{".jobs.personio.de", ".jobs.personio.com"} {
+		if company, ok := strings.CutSuffix(host, suffix); ok && company != "" && !strings.Contains(company, ".") {
+			// /job/<id> or the feed's /xml
+			return Target{ATS: personio.Source, Company: company, JobID: afterSegment(segments, "job")}, true
+		}
+	}
+
+	if company, ok := strings.CutSuffix(host, ".recruitee.com"); ok && company != "" && !strings.Contains(company, ".") {
+		// /o/<slug> or the API's /api/offers/<id or slug>
+		return Target{ATS: recruitee.Source, Company: company, JobID: cmp.Or(afterSegment(segments, "o"), afterSegment(segments, "offers"))}, true
+	}
+
+	if company, ok := strings.CutSuffix(host, ".teamtailor.com"); ok && company != "" && company != "api" && !strings.Contains(company, ".") {
+		// /jobs/<id>-<slug>, optionally behind a locale such as /sv
+		id, _, _ := strings.Cut(afterSegment(segments, "jobs"), "-")
+
+		return Target{ATS: teamtailor.Source, Company: company, JobID: id}, true
+	}
+
+	if tenant, ok := strings.CutSuffix(host, ".myworkdayjobs.com"); ok && strings.Count(tenant, ".") == 1 && !strings.HasPrefix(tenant, ".") {
+		return parseWorkday(tenant, segments), true
+	}
+
+	switch host {
+	case "boards.greenhouse.io", "job-boards.greenhouse.io":
+		return parseGreenhouse(segments, query), true
+	case "boards-api.greenhouse.io":
+		// /v1/boards/<company>/jobs/<id>
+		return Target{ATS: greenhouse.Source, Company: segment(segments, 2), JobID: afterSegment(segments, "jobs")}, segment(segments, 1) == "boards"
+	case "jobs.lever.co", "jobs.eu.lever.co":
+		// /<company>/<id>/apply
+		return Target{ATS: lever.Source, Company: segment(segments, 0), JobID: segment(segments, 1), eu: host == "jobs.eu.lever.co"}, true
+	case "api.lever.co", "api.eu.lever.co":
+		// /v0/postings/<company>/<id>
+		return Target{ATS: lever.Source, Company: segment(segments, 2), JobID: segment(segments, 3), eu: host == "api.eu.lever.co"}, segment(segments, 1) == "postings"
+	case "jobs.ashbyhq.com":
+		// /<company>/<id>/application, or the board's /<company>/embed script
+		target := Target{ATS: ashby.Source, Company: segment(segments, 0), JobID: segment(segments, 1)}
+		if target.JobID == "embed" {
+			target.JobID = ""
+		}
+
+		return target, true
+	case "api.ashbyhq.com":
+		// /posting-api/job-board/<company>
+		return Target{ATS: ashby.Source, Company: afterSegment(segments, "job-board")}, true
+	case "jobs.gem.com":
+		// /<company>/<id>
+		return Target{ATS: gem.Source, Company: segment(segments, 0), JobID: segment(segments, 1)}, true
+	case "ats.rippling.com":
+		return parseRippling(segments), true
+	case "apply.workable.com":
+		return parseWorkable(segments), true
+	case "jobs.smartrecruiters.com", "careers.smartrecruiters.com":
+		return parseSmartRecruiters(segments), true
+	case "api.smartrecruiters.com":
+		// /v1/companies/<company>/postings/<id>
+		return Target{ATS: smartrecruiters.Source, Company: afterSegment(segments, "companies"), JobID: afterSegment(segments, "postings")}, true
+	default:
+		return Target{}, false
+	}
+}
+
+// parseGreenhouse handles /<company>, /<company>/jobs/<id> and the embedded board pages,
+// /embed/job_board?for=<company> and /embed/job_app?for=<company>&token=<id>.
+func parseGreenhouse(segments []string, query url.Values) Target {
+	if segment(segments, 0) == "embed" {
+		return Target{ATS: greenhouse.Source, Company: query.Get("for"), JobID: query.Get("token")}
+	}
+
+	return Target{ATS: greenhouse.Source, Company: segment(segments, 0), JobID: afterSegment(segments, "jobs")}
+}
+
+// parseBamboo handles /careers, /careers/<id>, /careers/<id>/detail and the older
+// /jobs/view.php?id=<id>.
+func parseBamboo(company string, segments []string, query url.Values) Target {
+	target := Target{ATS: bamboo.Source, Company: company}
+
+	switch segment(segments, 0) {
+	case "careers":
+		if id := segment(segments, 1); id != "list" && id != "company-info" {
+			target.JobID = id
+		}
+	case "jobs":
+		target.JobID = query.Get("id")
+	}
+
+	return target
+}
+
+// parseRippling handles /<company>/jobs/<id>, optionally behind a locale such as /en-GB,
+// and the API's /api/v2/board/<company>/jobs/<id>.
+func parseRippling(segments []string) Target {
+	if segment(segments, 0) == "api" {
+		segments = segments[min(3, len(segments)):]
+	} else if locale := segment(segments, 0); len(locale) == len("en-GB") && locale[2] == '-' {
+		segments = segments[1:]
+	}
+
+	return Target{ATS: rippling.Source, Company: segment(segments, 0), JobID: afterSegment(segments, "jobs")}
+}
+
+// parseWorkable handles /<company>, /<company>/j/<shortcode>, the API's
+// /api/v3/accounts/<company>/jobs/<shortcode> and the widget's /api/v1/widget/accounts/<company>.
+func parseWorkable(segments []string) Target {
+	if segment(segments, 0) == "api" {
+		return Target{ATS: workable.Source, Company: afterSegment(segments, "accounts"), JobID: afterSegment(segments, "jobs")}
+	}
+
+	return Target{ATS: workable.Source, Company: segment(segments, 0), JobID: afterSegment(segments, "j")}
+}
+
+// parseWorkday handles /<site>, /<site>/job/<path>, optionally behind a locale such as
+// /en-US, and the API's /wday/cxs/<tenant>/<site>/job/<path>. The company is named
+// "<tenant>.<data center>/<site>" and the job ID is the posting's path after /job/.
+func parseWorkday(tenant string, segments []string) Target {
+	if segment(segments, 0) == "wday" {
+		segments = segments[min(3, len(segments)):]
+	} else if locale := segment(segments, 0); len(locale) == len("en-US") && locale[2] == '-' {
+		segments = segments[1:]
+	}
+
+	site := segment(segments, 0)
+	if site == "" {
+		return Target{ATS: workday.Source}
+	}
+
+	target := Target{ATS: workday.Source, Company: tenant + "/" + site}
+	if segment(segments, 1) == "job" && len(segments) > 2 {
+		target.JobID = strings.Join(segments[2:], "/")
+	}
+
+	return target
+}
+
+// parseSmartRecruiters handles /<company> and /<company>/<id>-<title slug>.
+func parseSmartRecruiters(segments []string) Target {
+	jobID, _, _ := strings.Cut(segment(segments, 1), "-")
+
+	return Target{ATS: smartrecruiters.Source, Company: segment(segments, 0), JobID: jobID}
+}
+
+// segment returns the ith path segment, or an empty string if there are fewer.
+func segment(segments []string, i int) string {
+	if i < len(segments) {
+		return segments[i]
+	}
+
+	return ""
+}
+
+// afterSegment returns the path segment following the first one equal to name, or an
+// empty string if there is none.
+func afterSegment(segments []string, name string) string {
+	for i, s := range segments {
+		if s == name {
+			return segment(segments, i+1)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,116 @@
+package ats
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/lever"
+)
+
+func TestParseURL(t *testing.T) {
+	t.Parallel()
+
+	const uuid = "c4b4c0a6-8d1e-4e5f-9a3b-2f6d7e8a9b0c"
+
+	tests := []struct {
+		url  string
+		want Target
+	}{
+		{url: "https://boards.greenhouse.io/acme/jobs/123", want: Target{ATS: "greenhouse", Company: "acme", JobID: "123"}},
+		{url: "https://job-boards.greenhouse.io/acme", want: Target{ATS: "greenhouse", Company: "acme"}},
+		{url: "https://boards.greenhouse.io/embed/job_app?for=acme&token=123", want: Target{ATS: "greenhouse", Company: "acme", JobID: "123"}},
+		{url: "https://boards-api.greenhouse.io/v1/boards/acme/jobs/123", want: Target{ATS: "greenhouse", Company: "acme", JobID: "123"}},
+		{url: "https://jobs.lever.co/acme/" + uuid, want: Target{ATS: "lever", Company: "acme", JobID: uuid}},
+		{url: "jobs.lever.co/acme/" + uuid + "/apply", want: Target{ATS: "lever", Company: "acme", JobID: uuid}},
+		{url: "https://jobs.eu.lever.co/acme", want: Target{ATS: "lever", Company: "acme", eu: true}},
+		{url: "https://jobs.ashbyhq.com/1password/" + uuid, want: Target{ATS: "ashby", Company: "1password", JobID: uuid}},
+		{url: "https://jobs.ashbyhq.com/1password/" + uuid + "/application", want: Target{ATS: "ashby", Company: "1password", JobID: uuid}},
+		{url: "//jobs.ashbyhq.com/1password/embed?version=2", want: Target{ATS: "ashby", Company: "1password"}},
+		{url: "https://api.ashbyhq.com/posting-api/job-board/1password", want: Target{ATS: "ashby", Company: "1password"}},
+		{url: "https://apply.workable.com/acme/j/ABC/", want: Target{ATS: "workable", Company: "acme", JobID: "ABC"}},
+		{url: "https://apply.workable.com/acme/", want: Target{ATS: "workable", Company: "acme"}},
+		{url: "https://acme.bamboohr.com/careers/25", want: Target{ATS: "bamboo", Company: "acme", JobID: "25"}},
+		{url: "https://acme.bamboohr.com/careers", want: Target{ATS: "bamboo", Company: "acme"}},
+		{url: "https://acme.bamboohr.com/jobs/view.php?id=25", want: Target{ATS: "bamboo", Company: "acme", JobID: "25"}},
+		{url: "https://ats.rippling.com/acme/jobs/" + uuid, want: Target{ATS: "rippling", Company: "acme", JobID: uuid}},
+		{url: "https://ats.rippling.com/en-GB/acme/jobs", want: Target{ATS: "rippling", Company: "acme"}},
+		{url: "https://jobs.gem.com/acme/am9icG9zdDox", want: Target{ATS: "gem", Company: "acme", JobID: "am9icG9zdDox"}},
+		{url: "HTTPS://WWW.Jobs.Gem.com/acme", want: Target{ATS: "gem", Company: "acme"}},
+		{url: "https://acme.jobs.personio.de/job/1482093?language=de", want: Target{ATS: "personio", Company: "acme", JobID: "1482093"}},
+		{url: "https://acme.jobs.personio.com/xml", want: Target{ATS: "personio", Company: "acme"}},
+		{url: "https://acme.recruitee.com/o/senior-frontend-engineer", want: Target{ATS: "recruitee", Company: "acme", JobID: "senior-frontend-engineer"}},
+		{url: "https://acme.recruitee.com/api/offers/", want: Target{ATS: "recruitee", Company: "acme"}},
+		{url: "https://acme.teamtailor.com/jobs/4211078-senior-platform-engineer", want: Target{ATS: "teamtailor", Company: "acme", JobID: "4211078"}},
+		{url: "https://acme.teamtailor.com/sv/jobs", want: Target{ATS: "teamtailor", Company: "acme"}},
+		{url: "https://jobs.smartrecruiters.com/Acme/744000089031835-senior-data-engineer", want: Target{ATS: "smartrecruiters", Company: "Acme", JobID: "744000089031835"}},
+		{url: "https://careers.smartrecruiters.com/Acme", want: Target{ATS: "smartrecruiters", Company: "Acme"}},
+		{url: "https://api.smartrecruiters.com/v1/companies/Acme/postings", want: Target{ATS: "smartrecruiters", Company: "Acme"}},
+		{url: "https://nvidia.wd5.myworkdayjobs.com/NVIDIAExternalCareerSite", want: Target{ATS: "workday", Company: "nvidia.wd5/NVIDIAExternalCareerSite"}},
+		{url: "https://acme.wd1.myworkdayjobs.com/en-US/External/job/US-TX-Austin/Engineer_R123", want: Target{ATS: "workday", Company: "acme.wd1/External", JobID: "US-TX-Austin/Engineer_R123"}},
+		{url: "https://acme.wd1.myworkdayjobs.com/wday/cxs/acme/External/jobs", want: Target{ATS: "workday", Company: "acme.wd1/External"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseURL(tt.url)
+		if err != nil {
+			t.Errorf("ParseURL(%s) error = %v", tt.url, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseURL(%s) = %+v, want %+v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestParseURLUnsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, rawURL := range []string{
+		"https://example.com/careers",
+		"https://boards.greenhouse.io/",
+		"https://bamboohr.com/careers",
+		"https://acme.wd1.myworkdayjobs.com/",
+		"https://api.lever.co/v1/other/acme",
+	} {
+		_, err := ParseURL(rawURL)
+		if !errors.Is(err, ErrUnsupportedURL) {
+			t.Errorf("ParseURL(%s) error = %v, want %v", rawURL, err, ErrUnsupportedURL)
+		}
+	}
+}
+
+func Test_withScheme(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"careers.acme.com":          "https://careers.acme.com",
+		"//careers.acme.com":        "https://careers.acme.com",
+		" http://careers.acme.com ": "http://careers.acme.com",
+		"https://careers.acme.com":  "https://careers.acme.com",
+	}
+
+	for rawURL, want := range tests {
+		if got := withScheme(rawURL); got != want {
+			t.Errorf("withScheme(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+func TestTargetLoader(t *testing.T) {
+	t.Parallel()
+
+	target, err := ParseURL("https://jobs.eu.lever.co/acme")
+	if err != nil {
+		t.Fatalf("ParseURL() error = %v", err)
+	}
+
+	loader, err := target.Loader()
+	if err != nil {
+		t.Fatalf("Loader() error = %v", err)
+	}
+
+	if loader != lever.New(lever.WithAPIURL(lever.EUAPIURL)) {
+		t.Errorf("Loader() = %+v, want a loader for the EU API", loader)
+	}
+}
@@ -4,6 +4,7 @@ package workable
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"strings"
 
@@ -12,56 +13,174 @@ import (
 	"github.com/buger/jsonparser"
 )
 
-var (
-	workableCompanyURL = "https://apply.workable.com/api/v3/accounts/%s/jobs"
-	workableJobURL     = "https://apply.workable.com/api/v2/accounts/%s/jobs/%s"
+// Source identifies jobs scraped from Workable and is the name the loader is registered under.
+const Source = "workable"
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Workable endpoints; use New to point it elsewhere.
+type Loader struct {
+	baseURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithBaseURL sets the base URL of the careers site and its API, DefaultBaseURL by default.
+func WithBaseURL(baseURL string) Option {
+	return func(l *Loader) {
+		l.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Workable.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Workable, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Workable, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Workable given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.baseURL == "" {
+		l.baseURL = DefaultBaseURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultBaseURL is the base URL of Workable's hosted careers sites and their API.
+	DefaultBaseURL = "https://apply.workable.com"
+
+	workableCompanyURL = "%s/api/v3/accounts/%s/jobs"
+	workableJobURL     = "%s/api/v2/accounts/%s/jobs/%s"
 )
 
 // ScrapeCompany scrapes all jobs for a given company from Workable ATS.
 func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
-	slog.DebugContext(ctx, "Scraping company", slog.String("ats", "workable"), slog.String("company_name", companyName))
+	result, err := ScrapeCompanyResult(ctx, companyName)
 
-	jobs := make([]*models.Job, 0)
+	return result.Jobs, err
+}
 
-	companyURL := fmt.Sprintf(workableCompanyURL, companyName)
+// ScrapeCompanyResult scrapes all jobs for a given company from Workable ATS, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
 
-	payload := strings.NewReader(`{"query":"","department":[],"location":[],"remote":[],"workplace":[],"worktype":[]}`)
+// StreamCompany scrapes all jobs for a given company from Workable ATS, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
 
-	body, err := helpers.PostJSON(ctx, companyURL, payload, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch jobs for company %s: %w", companyName, err)
-	}
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "workable"), slog.String("company_name", companyName))
 
-	_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
-		shortcode, err := jsonparser.GetString(value, "shortcode")
+		companyURL := fmt.Sprintf(workableCompanyURL, endpoints(ctx).baseURL, companyName)
+
+		payload := strings.NewReader(`{"query":"","department":[],"location":[],"remote":[],"workplace":[],"worktype":[]}`)
+
+		body, err := helpers.PostJSON(ctx, companyURL, payload, nil)
 		if err != nil {
-			slog.ErrorContext(ctx, "Failed to get job shortcode", slog.String("ats", "workable"), slog.String("company_name", companyName), slog.Any("error", err))
+			yield(nil, fmt.Errorf("failed to fetch jobs for company %s: %w", companyName, helpers.WrapNotFound(err, models.ErrBoardNotFound)))
 			return
 		}
 
-		job, err := ScrapeJob(ctx, companyName, shortcode)
+		shortcodes := make([]string, 0)
+		listErrs := make([]error, 0)
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			shortcode, err := jsonparser.GetString(value, "shortcode")
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to get job shortcode", slog.String("ats", "workable"), slog.String("company_name", companyName), slog.Any("error", err))
+				listErrs = append(listErrs, &models.JobError{Stage: models.StageList, Err: fmt.Errorf("failed to get job shortcode: %w", err)})
+
+				return
+			}
+
+			shortcodes = append(shortcodes, shortcode)
+		}, "results")
 		if err != nil {
-			slog.ErrorContext(ctx, "Failed to parse job", slog.String("ats", "workable"), slog.String("company_name", companyName), slog.Any("error", err))
+			yield(nil, fmt.Errorf("failed to parse jobs for company %s: %w", companyName, err))
 			return
 		}
 
-		jobs = append(jobs, job)
-	}, "results")
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse jobs for company %s: %w", companyName, err)
-	}
+		for _, err := range listErrs {
+			if !yield(nil, err) {
+				return
+			}
+		}
+
+		jobs := helpers.FetchEach(ctx, shortcodes, func(ctx context.Context, shortcode string) (*models.Job, error) {
+			job, err := ScrapeJob(ctx, companyName, shortcode)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to parse job", slog.String("ats", "workable"), slog.String("company_name", companyName), slog.String("job_id", shortcode), slog.Any("error", err))
+				return nil, &models.JobError{JobID: shortcode, Stage: models.StageDetail, Err: err}
+			}
+
+			return job, nil
+		})
+
+		for job, err := range jobs {
+			if err != nil && ctx.Err() != nil {
+				break
+			}
 
-	return jobs, nil
+			if !yield(job, err) {
+				return
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			yield(nil, fmt.Errorf("failed to fetch jobs for company %s: %w", companyName, err))
+		}
+	}
 }
 
 // ScrapeJob scrapes an individual job from Workable ATS given the company name and job ID.
 func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
 	slog.DebugContext(ctx, "Scraping job", slog.String("ats", "workable"), slog.String("company_name", companyName), slog.String("job_id", jobID))
-	url := fmt.Sprintf(workableJobURL, companyName, jobID)
+	url := fmt.Sprintf(workableJobURL, endpoints(ctx).baseURL, companyName, jobID)
 
 	body, err := helpers.GetJSON(ctx, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch job %s for company %s: %w", jobID, companyName, err)
+		return nil, fmt.Errorf("failed to fetch job %s for company %s: %w", jobID, companyName, helpers.WrapNotFound(err, models.ErrJobGone))
 	}
 
 	job, err := parseWorkableJob(ctx, body)
@@ -70,7 +189,7 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 	}
 
 	// https://apply.workable.com/darwin-ai/j/214D2728FC/
-	job.URL = fmt.Sprintf("https://apply.workable.com/%s/j/%s/", companyName, job.SourceID)
+	job.URL = fmt.Sprintf("%s/%s/j/%s/", endpoints(ctx).baseURL, companyName, job.SourceID)
 
 	return job, nil
 }
@@ -78,7 +197,7 @@ func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, err
 func parseWorkableJob(ctx context.Context, data []byte) (*models.Job, error) {
 	slog.DebugContext(ctx, "Parsing Workable job data", slog.String("ats", "workable"))
 
-	job := models.NewJob("workable", data)
+	job := models.NewJob(Source, data)
 
 	err := jsonparser.ObjectEach(job.GetSourceData(), func(key []byte, value []byte, _ jsonparser.ValueType, _ int) error {
 		switch string(key) {
@@ -0,0 +1,401 @@
+// Package workday contains functions to scrape job listings from Workday career sites.
+//
+// A company is named after its career site as "<tenant>.<data center>/<site>", both taken
+// from the site's URL: https://nvidia.wd5.myworkdayjobs.com/NVIDIAExternalCareerSite is
+// "nvidia.wd5/NVIDIAExternalCareerSite". Job IDs are a posting's path on the site after
+// /job/, such as "US-CA-Santa-Clara/Senior-Engineer_JR1992000".
+package workday
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+	"github.com/buger/jsonparser"
+)
+
+// Source identifies jobs scraped from Workday and is the name the loader is registered under.
+const Source = "workday"
+
+// ErrInvalidSite is returned for a company name that isn't a "<tenant>.<data center>/<site>"
+// career site.
+var ErrInvalidSite = errors.New("invalid Workday career site")
+
+// Loader implements the ats.Loader interface on top of the package-level scrape functions.
+// The zero value scrapes the public Workday career sites; use New to point it elsewhere.
+type Loader struct {
+	hostURL string
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithHostURL sets the format of a career site's host URL, with %s standing for its
+// "<tenant>.<data center>", DefaultHostURL by default.
+func WithHostURL(format string) Option {
+	return func(l *Loader) {
+		l.hostURL = strings.TrimSuffix(format, "/")
+	}
+}
+
+// New returns a Loader configured by opts.
+func New(opts ...Option) Loader {
+	l := Loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// ScrapeCompany scrapes all jobs for a given company from Workday.
+func (l Loader) ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	return ScrapeCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeCompanyResult scrapes all jobs for a given company from Workday, including the jobs that failed.
+func (l Loader) ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return ScrapeCompanyResult(l.withURLs(ctx), companyName)
+}
+
+// StreamCompany scrapes all jobs for a given company from Workday, yielding them as they arrive.
+func (l Loader) StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return StreamCompany(l.withURLs(ctx), companyName)
+}
+
+// ScrapeJob scrapes an individual job from Workday given the company name and job ID.
+func (l Loader) ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	return ScrapeJob(l.withURLs(ctx), companyName, jobID)
+}
+
+type loaderKey struct{}
+
+// withURLs returns a context that makes the package-level functions use the loader's URLs.
+func (l Loader) withURLs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// endpoints returns the loader carried by ctx, with the default URLs filled in.
+func endpoints(ctx context.Context) Loader {
+	l, _ := ctx.Value(loaderKey{}).(Loader)
+
+	if l.hostURL == "" {
+		l.hostURL = DefaultHostURL
+	}
+
+	return l
+}
+
+const (
+	// DefaultHostURL is the format of a career site's host URL, with %s standing for its
+	// "<tenant>.<data center>".
+	DefaultHostURL = "https://%s.myworkdayjobs.com"
+
+	workdayJobsURL    = "%s/wday/cxs/%s/%s/jobs"
+	workdayJobURL     = "%s/wday/cxs/%s/%s/job/%s"
+	workdayJobPageURL = "%s/%s/job/%s"
+
+	// pageSize is the most postings the search endpoint returns at once.
+	pageSize = 20
+	// maxPages bounds the search in case the site keeps returning postings.
+	maxPages = 500
+)
+
+// site is a company's career site.
+type site struct {
+	host   string
+	tenant string
+	name   string
+}
+
+// parseSite splits a "<tenant>.<data center>/<site>" company name into its career site.
+func parseSite(ctx context.Context, companyName string) (site, error) {
+	host, name, ok := strings.Cut(companyName, "/")
+	tenant, _, hasDataCenter := strings.Cut(host, ".")
+
+	if !ok || !hasDataCenter || tenant == "" || name == "" || strings.Contains(name, "/") {
+		return site{}, fmt.Errorf("%w: %q, want <tenant>.<data center>/<site>", ErrInvalidSite, companyName)
+	}
+
+	return site{host: fmt.Sprintf(endpoints(ctx).hostURL, host), tenant: tenant, name: name}, nil
+}
+
+// ScrapeCompany scrapes all job listings for a given company from Workday.
+func ScrapeCompany(ctx context.Context, companyName string) ([]*models.Job, error) {
+	result, err := ScrapeCompanyResult(ctx, companyName)
+
+	return result.Jobs, err
+}
+
+// ScrapeCompanyResult scrapes all job listings for a given company from Workday, reporting
+// the jobs that could not be scraped alongside the ones that could.
+func ScrapeCompanyResult(ctx context.Context, companyName string) (*models.ScrapeResult, error) {
+	return models.CollectResult(StreamCompany(ctx, companyName)) //nolint:wrapcheck // StreamCompany already wraps its errors
+}
+
+// StreamCompany scrapes all job listings for a given company from Workday, yielding each job
+// as soon as it has been fetched. Jobs that fail are yielded as a *models.JobError and the
+// stream carries on; any other error ends it. Breaking out of the loop stops the scrape.
+func StreamCompany(ctx context.Context, companyName string) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		ctx := helpers.WithATS(ctx, Source)
+
+		slog.DebugContext(ctx, "Scraping company", slog.String("ats", "workday"), slog.String("company_name", companyName))
+
+		site, err := parseSite(ctx, companyName)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		jobIDs, listErrs, err := searchJobs(ctx, site)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for _, err := range listErrs {
+			if !yield(nil, err) {
+				return
+			}
+		}
+
+		jobs := helpers.FetchEach(ctx, jobIDs, func(ctx context.Context, jobID string) (*models.Job, error) {
+			job, err := ScrapeJob(ctx, companyName, jobID)
+			if err != nil {
+				slog.ErrorContext(ctx, "Error scraping Workday job", slog.String("job_id", jobID), slog.Any("error", err))
+				return nil, &models.JobError{JobID: jobID, Stage: models.StageDetail, Err: err}
+			}
+
+			return job, nil
+		})
+
+		for job, err := range jobs {
+			if err != nil && ctx.Err() != nil {
+				break
+			}
+
+			if !yield(job, err) {
+				return
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			yield(nil, fmt.Errorf("error scraping Workday jobs: %w", err))
+		}
+	}
+}
+
+// searchJobs pages through the site's job search and returns the ID of every posting.
+// Workday only reports the total on the first page, so the search stops once that many
+// postings have been read or, without a total, once a page comes back empty.
+func searchJobs(ctx context.Context, site site) ([]string, []error, error) {
+	jobsURL := fmt.Sprintf(workdayJobsURL, site.host, site.tenant, site.name)
+	jobIDs := make([]string, 0)
+	listErrs := make([]error, 0)
+	// total stays negative when the first page doesn't report one
+	offset, total := 0, -1
+
+	for page := range maxPages {
+		payload := strings.NewReader(fmt.Sprintf(`{"appliedFacets":{},"limit":%d,"offset":%d,"searchText":""}`, pageSize, offset))
+
+		body, err := helpers.PostJSON(ctx, jobsURL, payload, nil)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error posting JSON to Workday jobs endpoint", slog.String("url", jobsURL), slog.Any("error", err))
+			return nil, nil, fmt.Errorf("error posting JSON to Workday jobs endpoint: %w", helpers.WrapNotFound(err, models.ErrBoardNotFound))
+		}
+
+		if page == 0 {
+			pageTotal, err := jsonparser.GetInt(body, "total")
+			if err == nil {
+				total = int(pageTotal)
+			}
+		}
+
+		postings := 0
+
+		_, err = jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			postings++
+
+			jobID, err := jobIDFromPath(value)
+			if err != nil {
+				slog.ErrorContext(ctx, "Error parsing job path from Workday job postings", slog.Any("error", err))
+				listErrs = append(listErrs, &models.JobError{Stage: models.StageList, Err: fmt.Errorf("error parsing job path from Workday job postings: %w", err)})
+
+				return
+			}
+
+			jobIDs = append(jobIDs, jobID)
+		}, "jobPostings")
+		if err != nil {
+			slog.ErrorContext(ctx, "Error parsing job postings from Workday jobs endpoint", slog.Any("error", err))
+			return nil, nil, fmt.Errorf("error parsing job postings from Workday jobs endpoint: %w", err)
+		}
+
+		offset += postings
+		if postings == 0 || (total >= 0 && offset >= total) {
+			break
+		}
+	}
+
+	return jobIDs, listErrs, nil
+}
+
+// jobIDFromPath returns the job ID from a posting's externalPath, e.g. /job/Remote/Engineer_R123.
+func jobIDFromPath(posting []byte) (string, error) {
+	path, err := jsonparser.GetString(posting, "externalPath")
+	if err != nil {
+		return "", fmt.Errorf("error getting externalPath: %w", err)
+	}
+
+	return strings.TrimPrefix(path, "/job/"), nil
+}
+
+// ScrapeJob scrapes an individual job listing from Workday.
+func ScrapeJob(ctx context.Context, companyName, jobID string) (*models.Job, error) {
+	ctx = helpers.WithATS(ctx, Source)
+
+	slog.DebugContext(ctx, "Scraping individual job", slog.String("ats", "workday"), slog.String("company_name", companyName), slog.String("job_id", jobID))
+
+	site, err := parseSite(ctx, companyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The URL is like https://nvidia.wd5.myworkdayjobs.com/wday/cxs/nvidia/NVIDIAExternalCareerSite/job/US-CA-Santa-Clara/Senior-Engineer_JR1992000
+	jobURL := fmt.Sprintf(workdayJobURL, site.host, site.tenant, site.name, jobID)
+
+	body, err := helpers.GetJSON(ctx, jobURL, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting JSON from Workday job endpoint", slog.String("url", jobURL), slog.Any("error", err))
+		return nil, fmt.Errorf("error getting JSON from Workday job endpoint: %w", helpers.WrapNotFound(err, models.ErrJobGone))
+	}
+
+	job, err := parseWorkdayJob(ctx, body, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	job.SourceID = jobID
+
+	if job.URL == "" {
+		job.URL = fmt.Sprintf(workdayJobPageURL, site.host, site.name, jobID)
+	}
+
+	return job, nil
+}
+
+func parseWorkdayJob(ctx context.Context, data []byte, now time.Time) (*models.Job, error) {
+	job := models.NewJob(Source, data)
+
+	info, _, _, err := jsonparser.Get(data, "jobPostingInfo")
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting jobPostingInfo from Workday job", slog.Any("error", err))
+		return nil, fmt.Errorf("error getting jobPostingInfo from Workday job: %w", err)
+	}
+
+	var postedOn string
+
+	err = jsonparser.ObjectEach(info, func(key []byte, value []byte, dataType jsonparser.ValueType, _ int) error {
+		switch string(key) {
+		case "title":
+			job.Title = string(value)
+		case "jobDescription":
+			job.Description = string(value)
+		case "location":
+			job.Location = string(value)
+		case "additionalLocations":
+			_, jerr := jsonparser.ArrayEach(value, func(location []byte, _ jsonparser.ValueType, _ int, _ error) {
+				job.AddMetadata("additionalLocations", string(location))
+			})
+			if jerr != nil {
+				slog.ErrorContext(ctx, "Error parsing additionalLocations array", slog.Any("error", jerr))
+			}
+		case "timeType":
+			job.EmploymentType = models.ParseEmploymentType(string(value))
+		case "remoteType":
+			// e.g. Remote, Hybrid or On-site
+			job.ProcessLocationType([]string{strings.ReplaceAll(string(value), "-", "")})
+		case "startDate":
+			job.ProcessDatePosted(ctx, value)
+		case "postedOn":
+			postedOn = string(value)
+		case "jobReqId":
+			job.AddMetadata("jobReqId", string(value))
+		case "externalUrl":
+			job.URL = string(value)
+		case "country":
+			country, err := jsonparser.GetString(value, "descriptor")
+			if err == nil {
+				job.AddMetadata("country", country)
+			}
+		default:
+			if dataType == jsonparser.String || dataType == jsonparser.Number || dataType == jsonparser.Boolean {
+				job.AddMetadata(string(key), string(value))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing Workday job object", slog.Any("error", err))
+		return nil, fmt.Errorf("error parsing Workday job object: %w", err)
+	}
+
+	if job.DatePosted.IsZero() && postedOn != "" {
+		job.DatePosted = parsePostedOn(postedOn, now)
+	}
+
+	name, err := jsonparser.GetString(data, "hiringOrganization", "name")
+	if err == nil {
+		job.Company.Name = name
+	}
+
+	homepage, err := jsonparser.GetString(data, "hiringOrganization", "url")
+	if err == nil && homepage != "" {
+		homepageURL, err := url.Parse(homepage)
+		if err == nil {
+			job.Company.Homepage = *homepageURL
+		}
+	}
+
+	return job, nil
+}
+
+// daysAgo matches the number of days in postedOn values such as "Posted 3 Days Ago".
+var daysAgo = regexp.MustCompile(`(\d+)\+? Days? Ago`)
+
+// parsePostedOn turns Workday's relative postedOn text, such as "Posted Today" or
+// "Posted 30+ Days Ago", into a date relative to now. It returns the zero time for text it
+// doesn't recognize.
+func parsePostedOn(postedOn string, now time.Time) time.Time {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	switch {
+	case strings.Contains(postedOn, "Today"):
+		return today
+	case strings.Contains(postedOn, "Yesterday"):
+		return today.AddDate(0, 0, -1)
+	}
+
+	match := daysAgo.FindStringSubmatch(postedOn)
+	if match == nil {
+		return time.Time{}
+	}
+
+	days, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	return today.AddDate(0, 0, -days)
+}
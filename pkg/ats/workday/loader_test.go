@@ -0,0 +1,213 @@
+package workday
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/h2non/gock"
+)
+
+//go:embed single_job.json
+var singleJob string
+
+//go:embed job_list.json
+var jobList string
+
+func Test_parseWorkdayJob(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	job, err := parseWorkdayJob(context.Background(), []byte(singleJob), now)
+	if err != nil {
+		t.Fatalf("parseWorkdayJob() error = %v", err)
+	}
+
+	if job.Title != "Senior Software Engineer, Cloud Infrastructure" {
+		t.Errorf("parseWorkdayJob() Title = %v, want %v", job.Title, "Senior Software Engineer, Cloud Infrastructure")
+	}
+
+	if job.Location != "US, CA, Santa Clara" {
+		t.Errorf("parseWorkdayJob() Location = %v, want %v", job.Location, "US, CA, Santa Clara")
+	}
+
+	if job.EmploymentType != models.FullTime {
+		t.Errorf("parseWorkdayJob() EmploymentType = %v, want %v", job.EmploymentType, models.FullTime)
+	}
+
+	if job.LocationType != models.HybridLocation {
+		t.Errorf("parseWorkdayJob() LocationType = %v, want %v", job.LocationType, models.HybridLocation)
+	}
+
+	if want := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC); !job.DatePosted.Equal(want) {
+		t.Errorf("parseWorkdayJob() DatePosted = %v, want %v", job.DatePosted, want)
+	}
+
+	if job.Company.Name != "NVIDIA" {
+		t.Errorf("parseWorkdayJob() Company.Name = %v, want %v", job.Company.Name, "NVIDIA")
+	}
+
+	if job.URL != "https://nvidia.wd5.myworkdayjobs.com/NVIDIAExternalCareerSite/job/US-CA-Santa-Clara/Senior-Software-Engineer--Cloud-Infrastructure_JR1992000" {
+		t.Errorf("parseWorkdayJob() URL = %v", job.URL)
+	}
+
+	if got := job.GetMetadata("jobReqId"); len(got) != 1 || got[0] != "JR1992000" {
+		t.Errorf("parseWorkdayJob() jobReqId = %v, want [JR1992000]", got)
+	}
+
+	// metadata values are split on commas, so the additional locations come back as their parts
+	if got := job.GetMetadata("additionalLocations"); !slices.Contains(got, "Seattle") {
+		t.Errorf("parseWorkdayJob() additionalLocations = %v, want Seattle among them", got)
+	}
+
+	if got := job.GetMetadata("country"); len(got) != 1 || got[0] != "United States of America" {
+		t.Errorf("parseWorkdayJob() country = %v, want [United States of America]", got)
+	}
+}
+
+func Test_parsePostedOn(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		postedOn string
+		want     time.Time
+	}{
+		{postedOn: "Posted Today", want: today},
+		{postedOn: "Posted Yesterday", want: today.AddDate(0, 0, -1)},
+		{postedOn: "Posted 1 Day Ago", want: today.AddDate(0, 0, -1)},
+		{postedOn: "Posted 3 Days Ago", want: today.AddDate(0, 0, -3)},
+		{postedOn: "Posted 30+ Days Ago", want: today.AddDate(0, 0, -30)},
+		{postedOn: "Posted a while back", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		if got := parsePostedOn(tt.postedOn, now); !got.Equal(tt.want) {
+			t.Errorf("parsePostedOn(%q) = %v, want %v", tt.postedOn, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeCompany(t *testing.T) {
+	t.Parallel()
+
+	defer gock.Off() // Flush pending mocks after test execution
+
+	gock.New("https://acme.wd1.myworkdayjobs.com").
+		Post("/wday/cxs/acme/External/jobs").
+		Reply(200).
+		JSON(jobList)
+
+	for _, path := range []string{
+		"US-CA-Santa-Clara/Senior-Software-Engineer--Cloud-Infrastructure_JR1992000",
+		"US-TX-Austin/Technical-Program-Manager_JR1991874",
+		"Remote/Hardware-Engineering-Intern---Summer-2027_JR1990412",
+	} {
+		gock.New("https://acme.wd1.myworkdayjobs.com").
+			Get("/wday/cxs/acme/External/job/" + path).
+			Reply(200).
+			JSON(singleJob)
+	}
+
+	jobs, err := ScrapeCompany(context.Background(), "acme.wd1/External")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 3 {
+		t.Fatalf("ScrapeCompany() len(jobs) = %v, want 3", len(jobs))
+	}
+
+	if jobs[1].SourceID != "US-TX-Austin/Technical-Program-Manager_JR1991874" {
+		t.Errorf("ScrapeCompany() jobs[1].SourceID = %v", jobs[1].SourceID)
+	}
+
+	gock.New("https://missing.wd1.myworkdayjobs.com").
+		Post("/wday/cxs/missing/External/jobs").
+		Reply(404)
+
+	_, err = ScrapeCompany(context.Background(), "missing.wd1/External")
+	if !errors.Is(err, models.ErrBoardNotFound) {
+		t.Errorf("ScrapeCompany() error = %v, want %v", err, models.ErrBoardNotFound)
+	}
+
+	_, err = ScrapeCompany(context.Background(), "acme")
+	if !errors.Is(err, ErrInvalidSite) {
+		t.Errorf("ScrapeCompany() error = %v, want %v", err, ErrInvalidSite)
+	}
+
+	// later pages report a total of zero, so the first page's total has to be kept
+	gock.New("https://paged.wd3.myworkdayjobs.com").
+		Post("/wday/cxs/paged/Careers/jobs").
+		BodyString(`"offset":0`).
+		Reply(200).
+		JSON(`{"total": 2, "jobPostings": [{"externalPath": "/job/Remote/First_R1"}]}`)
+
+	gock.New("https://paged.wd3.myworkdayjobs.com").
+		Post("/wday/cxs/paged/Careers/jobs").
+		BodyString(`"offset":1`).
+		Reply(200).
+		JSON(`{"total": 0, "jobPostings": [{"externalPath": "/job/Remote/Second_R2"}]}`)
+
+	for _, path := range []string{"Remote/First_R1", "Remote/Second_R2"} {
+		gock.New("https://paged.wd3.myworkdayjobs.com").
+			Get("/wday/cxs/paged/Careers/job/" + path).
+			Reply(200).
+			JSON(singleJob)
+	}
+
+	jobs, err = ScrapeCompany(context.Background(), "paged.wd3/Careers")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 2 {
+		t.Errorf("ScrapeCompany() len(jobs) = %v, want 2", len(jobs))
+	}
+
+	// without a total the search carries on until a page comes back empty
+	for offset, body := range []string{
+		`{"jobPostings": [{"externalPath": "/job/Remote/First_R1"}]}`,
+		`{"jobPostings": [{"externalPath": "/job/Remote/Second_R2"}]}`,
+		`{"jobPostings": []}`,
+	} {
+		gock.New("https://untotalled.wd5.myworkdayjobs.com").
+			Post("/wday/cxs/untotalled/Careers/jobs").
+			BodyString(fmt.Sprintf(`"offset":%d,`, offset)).
+			Reply(200).
+			JSON(body)
+	}
+
+	for _, path := range []string{"Remote/First_R1", "Remote/Second_R2"} {
+		gock.New("https://untotalled.wd5.myworkdayjobs.com").
+			Get("/wday/cxs/untotalled/Careers/job/" + path).
+			Reply(200).
+			JSON(singleJob)
+	}
+
+	jobs, err = ScrapeCompany(context.Background(), "untotalled.wd5/Careers")
+	if err != nil {
+		t.Fatalf("ScrapeCompany() error = %v", err)
+	}
+
+	if len(jobs) != 2 {
+		t.Errorf("ScrapeCompany() without a total len(jobs) = %v, want 2", len(jobs))
+	}
+
+	gock.New("https://gone.wd1.myworkdayjobs.com").
+		Get("/wday/cxs/gone/External/job/Remote/Closed_R9").
+		Reply(404)
+
+	_, err = ScrapeJob(context.Background(), "gone.wd1/External", "Remote/Closed_R9")
+	if !errors.Is(err, models.ErrJobGone) {
+		t.Errorf("ScrapeJob() error = %v, want %v", err, models.ErrJobGone)
+	}
+}
@@ -0,0 +1,116 @@
+package atsfake
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+)
+
+var (
+	ashbyBoardArg = regexp.MustCompile(`organizationHostedJobsPageName:\s*"([^"]*)"`)
+	ashbyJobArg   = regexp.MustCompile(`jobPostingId:\s*"([^"]*)"`)
+)
+
+// ashbyEmploymentTypes maps fixture employment types to Ashby's enum.
+var ashbyEmploymentTypes = map[string]string{
+	"full_time":  "FullTime",
+	"part_time":  "PartTime",
+	"contract":   "Contract",
+	"internship": "Intern",
+	"temporary":  "Temporary",
+}
+
+func (s *Server) ashbyJobBoard(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	jobs := make([]map[string]any, 0, len(company.Jobs))
+	for _, job := range company.Jobs {
+		jobs = append(jobs, map[string]any{
+			"id":          job.ID,
+			"title":       job.Title,
+			"department":  job.Department,
+			"location":    job.Location,
+			"publishedAt": job.PostedAt,
+			"jobUrl":      baseURL(r) + "/ashby/" + company.Slug + "/" + job.ID,
+		})
+	}
+
+	writeJSON(w, r, map[string]any{
+		"apiVersion": "1",
+		"jobs":       jobs,
+	})
+}
+
+// ashbyGraphQL answers the two queries the loader sends. Like Ashby, it finds the board
+// and posting from the arguments inlined in the query and answers unknown ones with null.
+func (s *Server) ashbyGraphQL(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		Query string `json:"query"`
+	}{}
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var company *Company
+
+	if match := ashbyBoardArg.FindStringSubmatch(request.Query); match != nil {
+		company, _ = s.fixtures.company(match[1])
+	}
+
+	switch r.URL.Query().Get("op") {
+	case "ApiJobPosting":
+		var posting any
+
+		if match := ashbyJobArg.FindStringSubmatch(request.Query); company != nil && match != nil {
+			if job, ok := company.job(match[1]); ok {
+				posting = ashbyJobPosting(job)
+			}
+		}
+
+		writeJSON(w, r, map[string]any{"data": map[string]any{"jobPosting": posting}})
+	case "ApiOrganizationFromHostedJobsPageName":
+		var organization any
+
+		if company != nil {
+			organization = map[string]any{
+				"name":          company.Name,
+				"publicWebsite": company.Homepage,
+				"timezone":      "UTC",
+				"theme":         map[string]any{"logoSquareImageUrl": company.Logo},
+				"__typename":    "Organization",
+			}
+		}
+
+		writeJSON(w, r, map[string]any{"data": map[string]any{"organization": organization}})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func ashbyJobPosting(job *Job) map[string]any {
+	employmentType, ok := ashbyEmploymentTypes[job.EmploymentType]
+	if !ok {
+		employmentType = job.EmploymentType
+	}
+
+	return map[string]any{
+		"id":                     job.ID,
+		"title":                  job.Title,
+		"departmentName":         job.Department,
+		"teamNames":              []string{job.Department},
+		"descriptionHtml":        job.Description,
+		"employmentType":         employmentType,
+		"isListed":               true,
+		"locationName":           job.Location,
+		"secondaryLocationNames": []string{},
+		"publishedDate":          job.PostedAt.Format("2006-01-02"),
+		"workplaceType":          job.workplace("Remote", "Hybrid", "OnSite", ""),
+	}
+}
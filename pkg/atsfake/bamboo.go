@@ -0,0 +1,96 @@
+package atsfake
+
+import (
+	"net/http"
+)
+
+// bambooEmploymentStatuses maps fixture employment types to BambooHR's labels.
+var bambooEmploymentStatuses = map[string]string{
+	"full_time":  "Full-Time",
+	"part_time":  "Part-Time",
+	"contract":   "Contractor",
+	"internship": "Intern",
+	"temporary":  "Temporary",
+}
+
+func (s *Server) bambooList(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	openings := make([]map[string]any, 0, len(company.Jobs))
+	for _, job := range company.Jobs {
+		openings = append(openings, map[string]any{
+			"id":                    job.ID,
+			"jobOpeningName":        job.Title,
+			"departmentLabel":       job.Department,
+			"employmentStatusLabel": bambooEmploymentStatus(job),
+			"location":              bambooLocation(job),
+			"isRemote":              job.Workplace == "remote",
+		})
+	}
+
+	writeJSON(w, r, map[string]any{
+		"meta":   map[string]any{"totalCount": len(openings)},
+		"result": openings,
+	})
+}
+
+func (s *Server) bambooDetail(w http.ResponseWriter, r *http.Request) {
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, r, map[string]any{
+		"meta": map[string]any{"isMultiLocationOpening": false},
+		"result": map[string]any{
+			"jobOpening": map[string]any{
+				"jobOpeningShareUrl":    baseURL(r) + "/bamboo/" + company.Slug + "/careers/" + job.ID,
+				"jobOpeningName":        job.Title,
+				"departmentLabel":       job.Department,
+				"employmentStatusLabel": bambooEmploymentStatus(job),
+				"description":           job.Description,
+				"datePosted":            job.PostedAt.Format("2006-01-02"),
+				"locationType":          job.workplace("1", "2", "0", ""),
+				"location":              bambooLocation(job),
+			},
+		},
+	})
+}
+
+func (s *Server) bambooCompanyInfo(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, r, map[string]any{
+		"result": map[string]any{
+			"name":           company.Name,
+			"careerShareUrl": baseURL(r) + "/bamboo/" + company.Slug + "/careers",
+			"logoUrl":        company.Logo,
+		},
+	})
+}
+
+func bambooEmploymentStatus(job *Job) string {
+	status, ok := bambooEmploymentStatuses[job.EmploymentType]
+	if !ok {
+		return job.EmploymentType
+	}
+
+	return status
+}
+
+func bambooLocation(job *Job) map[string]any {
+	return map[string]any{
+		"city":    job.City,
+		"state":   job.Region,
+		"country": job.Country,
+	}
+}
@@ -0,0 +1,102 @@
+package atsfake
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+//go:embed fixtures.json
+var defaultFixtures []byte
+
+// Fixtures is the data the fake server renders. It is ATS neutral: every company is
+// served by every ATS, in that ATS's own response format.
+type Fixtures struct {
+	Companies []*Company `json:"companies"`
+}
+
+// Company is a company and the jobs it has open.
+type Company struct {
+	// Slug is the board name used in ATS URLs, e.g. acme in boards-api.greenhouse.io/v1/boards/acme/jobs.
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Homepage    string `json:"homepage"`
+	Logo        string `json:"logo"`
+	Description string `json:"description"`
+	Jobs        []*Job `json:"jobs"`
+}
+
+// Job is a single job posting.
+type Job struct {
+	// ID is used as the job ID by every ATS, so it should be URL safe.
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Department string `json:"department"`
+	// Location is the display name, e.g. "Berlin, Germany".
+	Location string `json:"location"`
+	City     string `json:"city"`
+	Region   string `json:"region"`
+	// Country is an ISO 3166-1 alpha-2 code.
+	Country string `json:"country"`
+	// Workplace is remote, hybrid or onsite.
+	Workplace string `json:"workplace"`
+	// EmploymentType is a value models.ParseEmploymentType understands, e.g. full_time.
+	EmploymentType string    `json:"employment_type"`
+	Description    string    `json:"description"`
+	PostedAt       time.Time `json:"posted_at"`
+}
+
+// DefaultFixtures returns the fixtures bundled with the package.
+func DefaultFixtures() (*Fixtures, error) {
+	return LoadFixtures(bytes.NewReader(defaultFixtures))
+}
+
+// LoadFixtures decodes fixtures from JSON.
+func LoadFixtures(r io.Reader) (*Fixtures, error) {
+	fixtures := &Fixtures{}
+
+	err := json.NewDecoder(r).Decode(fixtures)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding fixtures: %w", err)
+	}
+
+	return fixtures, nil
+}
+
+// LoadFixturesFile decodes fixtures from a JSON file.
+func LoadFixturesFile(path string) (*Fixtures, error) {
+	file, err := os.Open(path) //nolint:gosec // the path is chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("error opening fixtures: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return LoadFixtures(file)
+}
+
+func (f *Fixtures) company(slug string) (*Company, bool) {
+	for _, company := range f.Companies {
+		if company.Slug == slug {
+			return company, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *Company) job(id string) (*Job, bool) {
+	for _, job := range c.Jobs {
+		if job.ID == id {
+			return job, true
+		}
+	}
+
+	return nil, false
+}
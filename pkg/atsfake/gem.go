@@ -0,0 +1,129 @@
+package atsfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// gemOperation is a single GraphQL operation. The batch endpoint takes either one of
+// them or an array of them.
+type gemOperation struct {
+	OperationName string `json:"operationName"`
+	Query         string `json:"query"`
+	Variables     struct {
+		BoardID string `json:"boardId"`
+		ExtID   string `json:"extId"`
+	} `json:"variables"`
+}
+
+func (s *Server) gemJobPosts(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	posts := make([]map[string]any, 0, len(company.Jobs))
+	for _, job := range company.Jobs {
+		posts = append(posts, map[string]any{
+			"id":                 job.ID,
+			"title":              job.Title,
+			"absolute_url":       baseURL(r) + "/gem/" + company.Slug + "/" + job.ID,
+			"content":            job.Description,
+			"departments":        []map[string]any{{"name": job.Department}},
+			"employment_type":    job.EmploymentType,
+			"first_published_at": job.PostedAt.Format(time.RFC3339),
+			"location":           map[string]any{"name": job.Location},
+			"location_type":      job.workplace("remote", "hybrid", "in_office", ""),
+		})
+	}
+
+	writeJSON(w, r, posts)
+}
+
+func (s *Server) gemGraphQL(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	batched := bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
+
+	operations := []gemOperation{}
+	if batched {
+		err = json.Unmarshal(body, &operations)
+	} else {
+		operations = append(operations, gemOperation{})
+		err = json.Unmarshal(body, &operations[0])
+	}
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	results := make([]map[string]any, 0, len(operations))
+	for _, operation := range operations {
+		results = append(results, map[string]any{"data": s.gemOperation(operation)})
+	}
+
+	if batched {
+		writeJSON(w, r, results)
+	} else {
+		writeJSON(w, r, results[0])
+	}
+}
+
+// gemOperation answers the job posting and branding theme queries, with nulls for boards
+// and postings that don't exist, as Gem does.
+func (s *Server) gemOperation(operation gemOperation) map[string]any {
+	company, ok := s.fixtures.company(operation.Variables.BoardID)
+
+	if strings.Contains(operation.Query, "publicBrandingTheme") {
+		var theme any
+
+		if ok {
+			theme = map[string]any{
+				"id":    company.Slug,
+				"theme": map[string]any{"ASSETS": map[string]any{"LOGO_URL": company.Logo}},
+			}
+		}
+
+		return map[string]any{"publicBrandingTheme": theme}
+	}
+
+	var posting any
+
+	if ok {
+		if job, found := company.job(operation.Variables.ExtID); found {
+			posting = map[string]any{
+				"id":                  "gem-" + job.ID,
+				"extId":               job.ID,
+				"title":               job.Title,
+				"descriptionHtml":     job.Description,
+				"firstPublishedTsSec": job.PostedAt.Unix(),
+				"locations": []map[string]any{{
+					"name":       job.Location,
+					"city":       job.City,
+					"isoCountry": job.Country,
+					"isRemote":   job.Workplace == "remote",
+				}},
+				"job": map[string]any{
+					"locationType":   job.workplace("remote", "hybrid", "in_office", ""),
+					"employmentType": job.EmploymentType,
+					"department":     map[string]any{"name": job.Department},
+				},
+			}
+		}
+	}
+
+	return map[string]any{
+		"oatsExternalJobPosting":        posting,
+		"oatsJobPostFieldsAndQuestions": nil,
+	}
+}
@@ -0,0 +1,66 @@
+package atsfake
+
+import (
+	"net/http"
+	"time"
+)
+
+func (s *Server) greenhouseJobs(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	jobs := make([]map[string]any, 0, len(company.Jobs))
+	for _, job := range company.Jobs {
+		jobs = append(jobs, greenhouseJob(r, company, job))
+	}
+
+	writeJSON(w, r, map[string]any{
+		"jobs": jobs,
+		"meta": map[string]any{"total": len(jobs)},
+	})
+}
+
+func (s *Server) greenhouseJob(w http.ResponseWriter, r *http.Request) {
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, r, greenhouseJob(r, company, job))
+}
+
+func (s *Server) greenhouseCompanyInfo(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, r, map[string]any{
+		"boardConfiguration": map[string]any{
+			"logo": map[string]any{
+				"href": company.Homepage,
+				"url":  company.Logo,
+			},
+		},
+	})
+}
+
+func greenhouseJob(r *http.Request, company *Company, job *Job) map[string]any {
+	return map[string]any{
+		"id":              job.numericID(),
+		"title":           job.Title,
+		"absolute_url":    baseURL(r) + "/greenhouse/" + company.Slug + "/jobs/" + job.ID,
+		"company_name":    company.Name,
+		"content":         job.Description,
+		"first_published": job.PostedAt.Format(time.RFC3339),
+		"updated_at":      job.PostedAt.Format(time.RFC3339),
+		"location":        map[string]any{"name": job.Location},
+		"departments":     []map[string]any{{"name": job.Department}},
+		"offices":         []map[string]any{{"name": job.Location, "location": job.Location}},
+	}
+}
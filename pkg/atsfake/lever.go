@@ -0,0 +1,103 @@
+package atsfake
+
+import (
+	"encoding/json"
+	"html/template"
+	"log/slog"
+	"net/http"
+)
+
+var leverJobPage = template.Must(template.New("job").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>{{.Title}}</title>
+<script type="application/ld+json">{{.LDJSON}}</script>
+</head>
+<body><h2>{{.Title}}</h2></body>
+</html>
+`))
+
+func (s *Server) leverPostings(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	postings := make([]map[string]any, 0, len(company.Jobs))
+	for _, job := range company.Jobs {
+		postings = append(postings, leverPosting(r, company, job))
+	}
+
+	writeJSON(w, r, postings)
+}
+
+func (s *Server) leverPosting(w http.ResponseWriter, r *http.Request) {
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, r, leverPosting(r, company, job))
+}
+
+// leverJobPage serves the hosted job page, which is where Lever exposes the company name
+// and logo, as LD+JSON.
+func (s *Server) leverJobPage(w http.ResponseWriter, r *http.Request) {
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	ldJSON, err := json.Marshal(map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "JobPosting",
+		"title":    job.Title,
+		"hiringOrganization": map[string]any{
+			"@type": "Organization",
+			"name":  company.Name,
+			"logo":  company.Logo,
+		},
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding fake Lever LD+JSON", slog.Any("error", err))
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err = leverJobPage.Execute(w, map[string]any{
+		"Title":  job.Title,
+		"LDJSON": template.JS(ldJSON), //nolint:gosec // the LD+JSON is marshalled from fixtures
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error rendering fake Lever job page", slog.Any("error", err))
+	}
+}
+
+func leverPosting(r *http.Request, company *Company, job *Job) map[string]any {
+	hostedURL := baseURL(r) + "/lever/" + company.Slug + "/" + job.ID
+
+	return map[string]any{
+		"id":   job.ID,
+		"text": job.Title,
+		"categories": map[string]any{
+			"commitment":   job.EmploymentType,
+			"department":   job.Department,
+			"team":         job.Department,
+			"location":     job.Location,
+			"allLocations": []string{job.Location},
+		},
+		"country":          job.Country,
+		"createdAt":        job.PostedAt.UnixMilli(),
+		"description":      job.Description,
+		"descriptionPlain": job.Description,
+		"hostedUrl":        hostedURL,
+		"applyUrl":         hostedURL + "/apply",
+		"workplaceType":    job.workplace("remote", "hybrid", "on-site", "unspecified"),
+	}
+}
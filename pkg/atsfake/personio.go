@@ -0,0 +1,71 @@
+package atsfake
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// personioEmploymentTypes maps fixture employment types to Personio's employment types and
+// schedules.
+var personioEmploymentTypes = map[string][2]string{
+	"full_time":  {"permanent", "full-time"},
+	"part_time":  {"permanent", "part-time"},
+	"contract":   {"freelance", "full-time"},
+	"internship": {"intern", "full-time"},
+	"temporary":  {"temporary", "full-time"},
+}
+
+type personioFeed struct {
+	XMLName   xml.Name           `xml:"workzag-jobs"`
+	Positions []personioPosition `xml:"position"`
+}
+
+type personioPosition struct {
+	ID                string                   `xml:"id"`
+	Subcompany        string                   `xml:"subcompany"`
+	Office            string                   `xml:"office"`
+	AdditionalOffices []string                 `xml:"additionalOffices>office,omitempty"`
+	Department        string                   `xml:"department"`
+	Name              string                   `xml:"name"`
+	JobDescriptions   []personioJobDescription `xml:"jobDescriptions>jobDescription"`
+	EmploymentType    string                   `xml:"employmentType"`
+	Schedule          string                   `xml:"schedule"`
+	CreatedAt         string                   `xml:"createdAt"`
+}
+
+type personioJobDescription struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value"`
+}
+
+func (s *Server) personioFeed(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	feed := personioFeed{Positions: make([]personioPosition, 0, len(company.Jobs))}
+	for _, job := range company.Jobs {
+		feed.Positions = append(feed.Positions, personioPosition{
+			ID:             job.ID,
+			Subcompany:     company.Name,
+			Office:         job.Location,
+			Department:     job.Department,
+			Name:           job.Title,
+			EmploymentType: personioEmploymentTypes[job.EmploymentType][0],
+			Schedule:       personioEmploymentTypes[job.EmploymentType][1],
+			CreatedAt:      job.PostedAt.UTC().Format("2006-01-02T15:04:05-07:00"),
+			JobDescriptions: []personioJobDescription{
+				{Name: "Job description", Value: job.Description},
+			},
+		})
+
+		// Personio has no remote flag, so remote work is listed as an office
+		if job.Workplace == "remote" {
+			feed.Positions[len(feed.Positions)-1].AdditionalOffices = []string{"Remote"}
+		}
+	}
+
+	writeXML(w, r, feed)
+}
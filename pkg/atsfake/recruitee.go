@@ -0,0 +1,67 @@
+package atsfake
+
+import (
+	"net/http"
+)
+
+// recruiteeEmploymentTypes maps fixture employment types to Recruitee's codes.
+var recruiteeEmploymentTypes = map[string]string{
+	"full_time":  "fulltime_permanent",
+	"part_time":  "parttime_permanent",
+	"contract":   "contract",
+	"internship": "internship",
+	"temporary":  "fulltime_fixed_term",
+}
+
+func (s *Server) recruiteeOffers(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	offers := make([]map[string]any, 0, len(company.Jobs))
+	for _, job := range company.Jobs {
+		offers = append(offers, recruiteeOffer(r, company, job))
+	}
+
+	writeJSON(w, r, map[string]any{"offers": offers})
+}
+
+func (s *Server) recruiteeOffer(w http.ResponseWriter, r *http.Request) {
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, r, map[string]any{"offer": recruiteeOffer(r, company, job)})
+}
+
+func recruiteeOffer(r *http.Request, company *Company, job *Job) map[string]any {
+	employmentType, ok := recruiteeEmploymentTypes[job.EmploymentType]
+	if !ok {
+		employmentType = job.EmploymentType
+	}
+
+	return map[string]any{
+		"id":                   job.numericID(),
+		"slug":                 job.ID,
+		"title":                job.Title,
+		"description":          job.Description,
+		"requirements":         "",
+		"location":             job.Location,
+		"city":                 job.City,
+		"country_code":         job.Country,
+		"remote":               job.Workplace == "remote",
+		"hybrid":               job.Workplace == "hybrid",
+		"on_site":              job.Workplace == "onsite",
+		"employment_type_code": employmentType,
+		"department":           job.Department,
+		"careers_url":          baseURL(r) + "/recruitee/" + company.Slug + "/o/" + job.ID,
+		"published_at":         job.PostedAt.UTC().Format("2006-01-02 15:04:05 MST"),
+		"company_name":         company.Name,
+		"salary":               map[string]any{"min": nil, "max": nil, "currency": nil, "period": nil},
+		"locations":            []map[string]any{{"city": job.City, "state": job.Region, "country": job.Country}},
+	}
+}
@@ -0,0 +1,64 @@
+package atsfake
+
+import (
+	"net/http"
+	"time"
+)
+
+func (s *Server) ripplingJobs(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	items := make([]map[string]any, 0, len(company.Jobs))
+	for _, job := range company.Jobs {
+		items = append(items, map[string]any{
+			"id":         job.ID,
+			"name":       job.Title,
+			"url":        ripplingJobURL(r, company, job),
+			"department": map[string]any{"name": job.Department},
+			"locations":  []map[string]any{{"name": job.Location}},
+		})
+	}
+
+	writeJSON(w, r, map[string]any{
+		"items":      items,
+		"page":       0,
+		"pageSize":   len(items),
+		"totalItems": len(items),
+		"totalPages": 1,
+	})
+}
+
+func (s *Server) ripplingJob(w http.ResponseWriter, r *http.Request) {
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, r, map[string]any{
+		"uuid": job.ID,
+		"name": job.Title,
+		"description": map[string]any{
+			"company": company.Description,
+			"role":    job.Description,
+		},
+		"workLocations":  []string{job.Location},
+		"department":     map[string]any{"name": job.Department},
+		"employmentType": map[string]any{"label": job.EmploymentType},
+		"createdOn":      job.PostedAt.Format(time.RFC3339),
+		"url":            ripplingJobURL(r, company, job),
+		"board": map[string]any{
+			"boardURL": company.Homepage,
+			"logo":     company.Logo,
+		},
+		"companyName": company.Name,
+	})
+}
+
+func ripplingJobURL(r *http.Request, company *Company, job *Job) string {
+	return baseURL(r) + "/rippling/" + company.Slug + "/jobs/" + job.ID
+}
@@ -0,0 +1,74 @@
+package atsfake
+
+import (
+	"strconv"
+	"strings"
+)
+
+// routes registers every ATS endpoint the loaders call, under the prefixes the Transport
+// rewrites the real hosts to.
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /greenhouse/v1/boards/{company}/jobs", s.greenhouseJobs)
+	s.mux.HandleFunc("GET /greenhouse/v1/boards/{company}/jobs/{id}", s.greenhouseJob)
+	s.mux.HandleFunc("GET /greenhouse/{company}", s.greenhouseCompanyInfo)
+
+	s.mux.HandleFunc("GET /lever/v0/postings/{company}", s.leverPostings)
+	s.mux.HandleFunc("GET /lever/v0/postings/{company}/{id}", s.leverPosting)
+	s.mux.HandleFunc("GET /lever/{company}/{id}", s.leverJobPage)
+
+	s.mux.HandleFunc("GET /ashby/posting-api/job-board/{company}", s.ashbyJobBoard)
+	s.mux.HandleFunc("POST /ashby/api/non-user-graphql", s.ashbyGraphQL)
+
+	s.mux.HandleFunc("GET /gem/job_board/v0/{company}/job_posts/{$}", s.gemJobPosts)
+	s.mux.HandleFunc("POST /gem/api/public/graphql/batch", s.gemGraphQL)
+
+	s.mux.HandleFunc("GET /bamboo/{company}/careers/list", s.bambooList)
+	s.mux.HandleFunc("GET /bamboo/{company}/careers/company-info", s.bambooCompanyInfo)
+	s.mux.HandleFunc("GET /bamboo/{company}/careers/{id}/detail", s.bambooDetail)
+
+	s.mux.HandleFunc("GET /personio/{company}/xml", s.personioFeed)
+
+	s.mux.HandleFunc("GET /recruitee/{company}/api/offers/{$}", s.recruiteeOffers)
+	s.mux.HandleFunc("GET /recruitee/{company}/api/offers/{id}", s.recruiteeOffer)
+
+	s.mux.HandleFunc("GET /rippling/api/v2/board/{company}/jobs", s.ripplingJobs)
+	s.mux.HandleFunc("GET /rippling/api/v2/board/{company}/jobs/{id}", s.ripplingJob)
+
+	s.mux.HandleFunc("GET /smartrecruiters/v1/companies/{company}/postings", s.smartRecruitersPostings)
+	s.mux.HandleFunc("GET /smartrecruiters/v1/companies/{company}/postings/{id}", s.smartRecruitersPosting)
+
+	s.mux.HandleFunc("POST /workable/api/v3/accounts/{company}/jobs", s.workableJobs)
+	s.mux.HandleFunc("GET /teamtailor/{company}/jobs.rss", s.teamtailorFeed)
+	s.mux.HandleFunc("GET /teamtailor/{company}/jobs/{id}", s.teamtailorJobPage)
+
+	s.mux.HandleFunc("GET /workable/api/v2/accounts/{company}/jobs/{id}", s.workableJob)
+
+	s.mux.HandleFunc("POST /workday/{host}/wday/cxs/{company}/{site}/jobs", s.workdayJobs)
+	s.mux.HandleFunc("GET /workday/{host}/wday/cxs/{company}/{site}/job/{path...}", s.workdayJob)
+}
+
+// workplace returns the ATS's spelling of the job's workplace, or unknown when the
+// fixture doesn't say.
+func (j *Job) workplace(remote, hybrid, onsite, unknown string) string {
+	switch strings.ToLower(j.Workplace) {
+	case "remote":
+		return remote
+	case "hybrid":
+		return hybrid
+	case "onsite":
+		return onsite
+	default:
+		return unknown
+	}
+}
+
+// numericID returns the job ID as a number for ATSs that use numeric IDs, falling back
+// to the string if it isn't one.
+func (j *Job) numericID() any {
+	id, err := strconv.ParseInt(j.ID, 10, 64)
+	if err != nil {
+		return j.ID
+	}
+
+	return id
+}
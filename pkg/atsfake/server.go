@@ -0,0 +1,230 @@
+// Package atsfake serves fake versions of the ATS APIs the loaders scrape, rendered from
+// fixture data, so that full pipeline runs can happen offline. Faults such as latency,
+// rate limiting and malformed payloads can be injected to exercise the loaders' retries
+// and error reporting.
+//
+// Each ATS is served under its own path prefix: /greenhouse, /lever, /ashby, /gem,
+// /bamboo/<company>, /personio/<company>, /recruitee/<company>, /rippling, /smartrecruiters,
+// /teamtailor/<company>, /workable and /workday/<tenant>.<data center>. Loaders can be
+// pointed at those prefixes with their base URL options, or a Transport can rewrite requests
+// for the real ATS hosts to them.
+package atsfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Fault describes a failure to inject into the responses of a Server.
+type Fault struct {
+	// Path limits the fault to requests whose path contains it. Empty matches every request.
+	Path string
+	// Every applies the fault to every nth matching request, starting with the first.
+	// Zero or one applies it to all of them.
+	Every int
+	// Latency delays the response.
+	Latency time.Duration
+	// Status replaces the response with an empty one with this status, e.g. 429.
+	Status int
+	// RetryAfter is sent as the Retry-After header along with Status, rounded up to seconds.
+	RetryAfter time.Duration
+	// Malformed cuts the response body in half so that it no longer parses.
+	Malformed bool
+}
+
+type fault struct {
+	Fault
+
+	requests atomic.Int64
+}
+
+// applies counts a request for the fault and reports whether the fault should be applied to it.
+func (f *fault) applies(r *http.Request) bool {
+	if !strings.Contains(r.URL.Path, f.Path) {
+		return false
+	}
+
+	n := f.requests.Add(1)
+
+	return f.Every <= 1 || (n-1)%int64(f.Every) == 0
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithFaults injects faults into the server's responses. When several faults apply to a
+// request their latencies add up, the first status wins and any of them can make the body
+// malformed.
+func WithFaults(faults ...Fault) Option {
+	return func(s *Server) {
+		for _, f := range faults {
+			s.faults = append(s.faults, &fault{Fault: f})
+		}
+	}
+}
+
+// Server is an http.Handler serving every supported ATS from the same fixtures. It is safe
+// for concurrent use.
+type Server struct {
+	fixtures *Fixtures
+	faults   []*fault
+	mux      *http.ServeMux
+}
+
+// New returns a server rendering fixtures.
+func New(fixtures *Fixtures, opts ...Option) *Server {
+	s := &Server{
+		fixtures: fixtures,
+		mux:      http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.routes()
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		latency    time.Duration
+		status     int
+		retryAfter time.Duration
+		malformed  bool
+	)
+
+	for _, f := range s.faults {
+		if !f.applies(r) {
+			continue
+		}
+
+		latency += f.Latency
+		malformed = malformed || f.Malformed
+
+		if status == 0 {
+			status = f.Status
+			retryAfter = f.RetryAfter
+		}
+	}
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+
+	if status != 0 {
+		if retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int((retryAfter+time.Second-1)/time.Second)))
+		}
+
+		w.WriteHeader(status)
+
+		return
+	}
+
+	buffered := &bufferedResponse{header: w.Header(), status: http.StatusOK}
+	s.mux.ServeHTTP(buffered, r)
+
+	body := buffered.body.Bytes()
+	if malformed {
+		body = body[:len(body)/2]
+	}
+
+	w.WriteHeader(buffered.status)
+	_, _ = w.Write(body)
+}
+
+// bufferedResponse holds a response back so that faults can be applied to its body.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	return b.body.Write(data) //nolint:wrapcheck // writing to a buffer never fails
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
+
+// baseURL returns the URL the request was made to, without a path, so rendered links
+// point back at the fake server.
+func baseURL(r *http.Request) string {
+	if r.TLS != nil {
+		return "https://" + r.Host
+	}
+
+	return "http://" + r.Host
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding fake ATS response", slog.String("path", r.URL.Path), slog.Any("error", err))
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func writeXML(w http.ResponseWriter, r *http.Request, v any) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding fake ATS response", slog.String("path", r.URL.Path), slog.Any("error", err))
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(data)
+}
+
+func notFound(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"error":"not found"}`))
+}
+
+// lookup returns the company and job named by the request's company and id path values.
+func (s *Server) lookup(r *http.Request) (*Company, *Job, bool) {
+	company, ok := s.fixtures.company(r.PathValue("company"))
+	if !ok {
+		return nil, nil, false
+	}
+
+	id := r.PathValue("id")
+	if id == "" {
+		return company, nil, true
+	}
+
+	job, ok := company.job(id)
+
+	return company, job, ok
+}
@@ -0,0 +1,264 @@
+package atsfake
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/ashby"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/bamboo"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/gem"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/greenhouse"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/lever"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/personio"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/recruitee"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/rippling"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/smartrecruiters"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/teamtailor"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/workable"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/workday"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+)
+
+// sources lists the ATSs the server fakes.
+var sources = []string{"ashby", "bamboo", "gem", "greenhouse", "lever", "personio", "recruitee", "rippling", "smartrecruiters", "teamtailor", "workable", "workday"}
+
+// companyName returns the name the source's loader knows a fixture company by. Workday
+// names a company after its career site rather than a single slug.
+func companyName(source, slug string) string {
+	if source == "workday" {
+		return slug + ".wd1/External"
+	}
+
+	return slug
+}
+
+// useServer points the package-level HTTP client at a fake server for the rest of the test.
+func useServer(t *testing.T, opts ...Option) {
+	t.Helper()
+
+	fixtures, err := DefaultFixtures()
+	if err != nil {
+		t.Fatalf("DefaultFixtures() error = %v", err)
+	}
+
+	server := httptest.NewServer(New(fixtures, opts...))
+	t.Cleanup(server.Close)
+
+	transport, err := NewTransport(server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+
+	config := helpers.DefaultClientConfig
+	config.BaseDelay = time.Millisecond
+	config.MaxDelay = time.Millisecond
+
+	clientOptions := []helpers.ClientOption{helpers.WithClientConfig(config), helpers.WithRateLimit(helpers.RateLimit{})}
+	for _, source := range sources {
+		clientOptions = append(clientOptions, helpers.WithATSRateLimit(source, helpers.RateLimit{}))
+	}
+
+	helpers.SetClient(helpers.NewClient(&http.Client{Transport: transport}, clientOptions...))
+	t.Cleanup(helpers.ResetHTTPClient)
+}
+
+//nolint:paralleltest // replaces the package-level HTTP client
+func TestServerScrapeCompany(t *testing.T) {
+	useServer(t)
+
+	for _, source := range sources {
+		result, err := ats.ScrapeCompanyResult(t.Context(), source, companyName(source, "acme"))
+		if err != nil {
+			t.Fatalf("ScrapeCompanyResult(%s) error = %v", source, err)
+		}
+
+		if len(result.Errors) != 0 {
+			t.Errorf("ScrapeCompanyResult(%s) errors = %v, want none", source, result.Errors)
+		}
+
+		titles := make([]string, 0, len(result.Jobs))
+		for _, job := range result.Jobs {
+			titles = append(titles, job.Title)
+		}
+
+		slices.Sort(titles)
+
+		want := []string{"Account Executive", "Product Design Intern", "Senior Backend Engineer"}
+		if !slices.Equal(titles, want) {
+			t.Errorf("ScrapeCompanyResult(%s) titles = %v, want %v", source, titles, want)
+		}
+
+		// Workable has no company information to scrape
+		if source != "workable" && len(result.Jobs) > 0 && result.Jobs[0].Company.Name == "" {
+			t.Errorf("ScrapeCompanyResult(%s) company name is empty", source)
+		}
+	}
+}
+
+//nolint:paralleltest // replaces the package-level HTTP client
+func TestServerMissing(t *testing.T) {
+	useServer(t)
+
+	for _, source := range sources {
+		_, err := ats.ScrapeCompanyResult(t.Context(), source, companyName(source, "initech"))
+		if err == nil {
+			t.Errorf("ScrapeCompanyResult(%s) for an unknown company error = nil, want an error", source)
+		}
+	}
+
+	_, err := ats.ScrapeCompanyResult(t.Context(), "greenhouse", "initech")
+	if !errors.Is(err, models.ErrBoardNotFound) {
+		t.Errorf("ScrapeCompanyResult(greenhouse) error = %v, want %v", err, models.ErrBoardNotFound)
+	}
+
+	loader, err := ats.Get("ashby")
+	if err != nil {
+		t.Fatalf("Get(ashby) error = %v", err)
+	}
+
+	_, err = loader.ScrapeJob(t.Context(), "acme", "9999")
+	if !errors.Is(err, models.ErrJobGone) {
+		t.Errorf("ScrapeJob(ashby) error = %v, want %v", err, models.ErrJobGone)
+	}
+}
+
+//nolint:paralleltest // replaces the package-level HTTP client
+func TestServerFaults(t *testing.T) {
+	useServer(t, WithFaults(
+		// every other request for the job is rate limited, so it succeeds on its first retry
+		Fault{Path: "/rippling/api/v2/board/acme/jobs/1001", Every: 2, Status: http.StatusTooManyRequests},
+		Fault{Path: "/lever/v0/postings/", Malformed: true},
+		Fault{Path: "/workable/", Latency: 10 * time.Millisecond},
+	))
+
+	result, err := ats.ScrapeCompanyResult(t.Context(), "rippling", "acme")
+	if err != nil || len(result.Jobs) != 3 {
+		t.Errorf("ScrapeCompanyResult(rippling) = %d jobs, %v, want 3 jobs after retries", len(result.Jobs), err)
+	}
+
+	_, err = ats.ScrapeCompanyResult(t.Context(), "lever", "acme")
+	if err == nil {
+		t.Error("ScrapeCompanyResult(lever) with a malformed payload error = nil, want an error")
+	}
+
+	start := time.Now()
+
+	result, err = ats.ScrapeCompanyResult(t.Context(), "workable", "acme")
+	if err != nil || len(result.Jobs) != 3 {
+		t.Errorf("ScrapeCompanyResult(workable) = %d jobs, %v, want 3 jobs", len(result.Jobs), err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("ScrapeCompanyResult(workable) took %v, want the injected latency", elapsed)
+	}
+}
+
+func TestLoaderOptions(t *testing.T) {
+	t.Parallel()
+
+	fixtures, err := DefaultFixtures()
+	if err != nil {
+		t.Fatalf("DefaultFixtures() error = %v", err)
+	}
+
+	// the loaders are pointed at the server directly, so the package-level client is left
+	// alone; each gets a server of its own since rate limits are kept per host
+	loaders := map[string]func(baseURL string) ats.Loader{
+		"ashby": func(baseURL string) ats.Loader {
+			return ashby.New(ashby.WithAPIURL(baseURL+"/ashby"), ashby.WithJobsURL(baseURL+"/ashby/"))
+		},
+		"bamboo": func(baseURL string) ats.Loader {
+			return bamboo.New(bamboo.WithCompanyURL(baseURL + "/bamboo/%s"))
+		},
+		"greenhouse": func(baseURL string) ats.Loader {
+			return greenhouse.New(greenhouse.WithBoardsAPIURL(baseURL+"/greenhouse"), greenhouse.WithJobBoardsURL(baseURL+"/greenhouse"))
+		},
+		"lever": func(baseURL string) ats.Loader {
+			return lever.New(lever.WithAPIURL(baseURL + "/lever"))
+		},
+		"personio": func(baseURL string) ats.Loader {
+			return personio.New(personio.WithCompanyURL(baseURL + "/personio/%s"))
+		},
+		"recruitee": func(baseURL string) ats.Loader {
+			return recruitee.New(recruitee.WithCompanyURL(baseURL + "/recruitee/%s"))
+		},
+		"rippling": func(baseURL string) ats.Loader {
+			return rippling.New(rippling.WithBaseURL(baseURL + "/rippling"))
+		},
+		"smartrecruiters": func(baseURL string) ats.Loader {
+			return smartrecruiters.New(smartrecruiters.WithAPIURL(baseURL+"/smartrecruiters"), smartrecruiters.WithJobsURL(baseURL+"/smartrecruiters"))
+		},
+		"teamtailor": func(baseURL string) ats.Loader {
+			return teamtailor.New(teamtailor.WithCompanyURL(baseURL + "/teamtailor/%s"))
+		},
+		"workable": func(baseURL string) ats.Loader {
+			return workable.New(workable.WithBaseURL(baseURL + "/workable"))
+		},
+	}
+
+	for source, newLoader := range loaders {
+		server := httptest.NewServer(New(fixtures))
+		t.Cleanup(server.Close)
+
+		job, err := newLoader(server.URL).ScrapeJob(t.Context(), "acme", "1001")
+		if err != nil {
+			t.Errorf("%s ScrapeJob() error = %v", source, err)
+			continue
+		}
+
+		if job.Title != "Senior Backend Engineer" || !strings.HasPrefix(job.URL, server.URL) {
+			t.Errorf("%s ScrapeJob() = %q at %s, want Senior Backend Engineer at %s", source, job.Title, job.URL, server.URL)
+		}
+	}
+
+	server := httptest.NewServer(New(fixtures))
+	t.Cleanup(server.Close)
+
+	job, err := workday.New(workday.WithHostURL(server.URL+"/workday/%s")).ScrapeJob(t.Context(), "acme.wd1/External", "US/Senior-Backend-Engineer_1001")
+	if err != nil || job.Title != "Senior Backend Engineer" || !strings.HasPrefix(job.URL, server.URL) {
+		t.Errorf("workday ScrapeJob() = %v, %v, want Senior Backend Engineer at %s", job, err, server.URL)
+	}
+
+	// Gem's single job parser doesn't handle the batch response yet, so scrape the board instead
+	jobs, err := gem.New(gem.WithAPIURL(server.URL+"/gem"), gem.WithJobsURL(server.URL+"/gem")).ScrapeCompany(t.Context(), "globex")
+	if err != nil || len(jobs) != 1 || jobs[0].Company.Logo.String() != "https://globex.example.com/logo.svg" {
+		t.Errorf("gem ScrapeCompany() = %v, %v, want the Globex job and logo", jobs, err)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		host   string
+		want   string
+		wantOK bool
+	}{
+		{host: "boards-api.greenhouse.io", want: "/greenhouse", wantOK: true},
+		{host: "api.eu.lever.co", want: "/lever", wantOK: true},
+		{host: "Acme.BambooHR.com", want: "/bamboo/acme", wantOK: true},
+		{host: "bamboohr.com", want: "", wantOK: false},
+		{host: "acme.jobs.personio.de", want: "/personio/acme", wantOK: true},
+		{host: "acme.jobs.personio.com", want: "/personio/acme", wantOK: true},
+		{host: "acme.recruitee.com", want: "/recruitee/acme", wantOK: true},
+		{host: "acme.teamtailor.com", want: "/teamtailor/acme", wantOK: true},
+		{host: "api.teamtailor.com", want: "", wantOK: false},
+		{host: "acme.wd1.myworkdayjobs.com", want: "/workday/acme.wd1", wantOK: true},
+		{host: "myworkdayjobs.com", want: "", wantOK: false},
+		{host: "example.com", want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Prefix(tt.host)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Prefix(%q) = %q, %v, want %q, %v", tt.host, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
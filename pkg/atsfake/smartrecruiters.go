@@ -0,0 +1,87 @@
+package atsfake
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// smartRecruitersEmploymentTypes maps fixture employment types to SmartRecruiters' labels.
+var smartRecruitersEmploymentTypes = map[string]string{
+	"full_time":  "Full-time",
+	"part_time":  "Part-time",
+	"contract":   "Contract",
+	"internship": "Internship",
+	"temporary":  "Temporary",
+}
+
+func (s *Server) smartRecruitersPostings(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	jobs := company.Jobs[min(max(offset, 0), len(company.Jobs)):]
+	if limit > 0 {
+		jobs = jobs[:min(limit, len(jobs))]
+	}
+
+	content := make([]map[string]any, 0, len(jobs))
+	for _, job := range jobs {
+		content = append(content, smartRecruitersPosting(company, job))
+	}
+
+	writeJSON(w, r, map[string]any{
+		"offset":     offset,
+		"limit":      limit,
+		"totalFound": len(company.Jobs),
+		"content":    content,
+	})
+}
+
+func (s *Server) smartRecruitersPosting(w http.ResponseWriter, r *http.Request) {
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	posting := smartRecruitersPosting(company, job)
+	posting["postingUrl"] = baseURL(r) + "/smartrecruiters/" + company.Slug + "/" + job.ID
+	posting["jobAd"] = map[string]any{
+		"sections": map[string]any{
+			"companyDescription": map[string]any{"title": "Company Description", "text": company.Description},
+			"jobDescription":     map[string]any{"title": "Job Description", "text": job.Description},
+		},
+	}
+
+	writeJSON(w, r, posting)
+}
+
+func smartRecruitersPosting(company *Company, job *Job) map[string]any {
+	employmentType, ok := smartRecruitersEmploymentTypes[job.EmploymentType]
+	if !ok {
+		employmentType = job.EmploymentType
+	}
+
+	return map[string]any{
+		"id":           job.ID,
+		"name":         job.Title,
+		"releasedDate": job.PostedAt.Format(time.RFC3339),
+		"company":      map[string]any{"identifier": company.Slug, "name": company.Name},
+		"location": map[string]any{
+			"city":         job.City,
+			"region":       job.Region,
+			"country":      job.Country,
+			"remote":       job.Workplace == "remote",
+			"hybrid":       job.Workplace == "hybrid",
+			"fullLocation": job.Location,
+		},
+		"department":       map[string]any{"label": job.Department},
+		"typeOfEmployment": map[string]any{"label": employmentType},
+	}
+}
@@ -0,0 +1,148 @@
+package atsfake
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+var teamtailorJobPage = template.Must(template.New("job").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>{{.Title}}</title>
+<script type="application/ld+json">{{.LDJSON}}</script>
+</head>
+<body><h1>{{.Title}}</h1></body>
+</html>
+`))
+
+// teamtailorEmploymentTypes maps fixture employment types to schema.org's, which Teamtailor
+// uses in a job page's LD+JSON.
+var teamtailorEmploymentTypes = map[string]string{
+	"full_time":  "FULL_TIME",
+	"part_time":  "PART_TIME",
+	"contract":   "CONTRACTOR",
+	"internship": "INTERN",
+	"temporary":  "TEMPORARY",
+}
+
+type teamtailorFeed struct {
+	XMLName   xml.Name `xml:"rss"`
+	Version   string   `xml:"version,attr"`
+	Namespace string   `xml:"xmlns:tt,attr"`
+	Channel   struct {
+		Title string           `xml:"title"`
+		Link  string           `xml:"link"`
+		Items []teamtailorItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type teamtailorItem struct {
+	Title        string               `xml:"title"`
+	Description  string               `xml:"description"`
+	PubDate      string               `xml:"pubDate"`
+	Link         string               `xml:"link"`
+	GUID         string               `xml:"guid"`
+	RemoteStatus string               `xml:"remoteStatus"`
+	Department   string               `xml:"tt:department"`
+	Locations    []teamtailorLocation `xml:"tt:locations>tt:location"`
+}
+
+type teamtailorLocation struct {
+	Name    string `xml:"tt:name"`
+	City    string `xml:"tt:city"`
+	Country string `xml:"tt:country"`
+}
+
+// teamtailorFeed serves the careers site's RSS feed. The JSON:API isn't faked, since its
+// keys belong to a single company, so loaders without a key read the feed.
+func (s *Server) teamtailorFeed(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	feed := teamtailorFeed{Version: "2.0", Namespace: "https://teamtailor.com/locations"}
+	feed.Channel.Title = company.Name
+	feed.Channel.Link = baseURL(r) + "/teamtailor/" + company.Slug
+
+	for _, job := range company.Jobs {
+		link := teamtailorJobURL(r, company, job)
+
+		feed.Channel.Items = append(feed.Channel.Items, teamtailorItem{
+			Title:        job.Title,
+			Description:  job.Description,
+			PubDate:      job.PostedAt.UTC().Format(time.RFC1123Z),
+			Link:         link,
+			GUID:         link,
+			RemoteStatus: job.workplace("fully", "hybrid", "none", ""),
+			Department:   job.Department,
+			Locations:    []teamtailorLocation{{Name: job.Location, City: job.City, Country: job.Country}},
+		})
+	}
+
+	writeXML(w, r, feed)
+}
+
+// teamtailorJobPage serves a job's page on the careers site, with the job as LD+JSON.
+func (s *Server) teamtailorJobPage(w http.ResponseWriter, r *http.Request) {
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	posting := map[string]any{
+		"@context":       "https://schema.org",
+		"@type":          "JobPosting",
+		"title":          job.Title,
+		"description":    job.Description,
+		"datePosted":     job.PostedAt.UTC().Format(time.RFC3339),
+		"employmentType": teamtailorEmploymentTypes[job.EmploymentType],
+		"hiringOrganization": map[string]any{
+			"@type":  "Organization",
+			"name":   company.Name,
+			"sameAs": company.Homepage,
+			"logo":   company.Logo,
+		},
+		"jobLocation": map[string]any{
+			"@type": "Place",
+			"address": map[string]any{
+				"@type":           "PostalAddress",
+				"addressLocality": job.City,
+				"addressRegion":   job.Region,
+				"addressCountry":  job.Country,
+			},
+		},
+	}
+
+	if job.Workplace == "remote" {
+		posting["jobLocationType"] = "TELECOMMUTE"
+	}
+
+	ldJSON, err := json.Marshal(posting)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error encoding fake Teamtailor LD+JSON", slog.Any("error", err))
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err = teamtailorJobPage.Execute(w, map[string]any{
+		"Title":  job.Title,
+		"LDJSON": template.JS(ldJSON), //nolint:gosec // the LD+JSON is marshalled from fixtures
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error rendering fake Teamtailor job page", slog.Any("error", err))
+	}
+}
+
+func teamtailorJobURL(r *http.Request, company *Company, job *Job) string {
+	return baseURL(r) + "/teamtailor/" + company.Slug + "/jobs/" + job.ID
+}
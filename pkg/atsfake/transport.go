@@ -0,0 +1,108 @@
+package atsfake
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidURL is returned by NewTransport for a server URL without a scheme and host.
+var ErrInvalidURL = errors.New("fake ATS server URL must be absolute")
+
+// prefixes maps the hosts the loaders call to the path prefix the server serves them under.
+var prefixes = map[string]string{
+	"boards-api.greenhouse.io": "/greenhouse",
+	"job-boards.greenhouse.io": "/greenhouse",
+	"api.lever.co":             "/lever",
+	"api.eu.lever.co":          "/lever",
+	"jobs.lever.co":            "/lever",
+	"jobs.eu.lever.co":         "/lever",
+	"api.ashbyhq.com":          "/ashby",
+	"jobs.ashbyhq.com":         "/ashby",
+	"api.gem.com":              "/gem",
+	"jobs.gem.com":             "/gem",
+	"ats.rippling.com":         "/rippling",
+	"apply.workable.com":       "/workable",
+	"api.smartrecruiters.com":  "/smartrecruiters",
+	"jobs.smartrecruiters.com": "/smartrecruiters",
+}
+
+// Transport is an http.RoundTripper that sends requests for the real ATS hosts to a fake
+// server instead, and every other request through unchanged.
+type Transport struct {
+	base *url.URL
+	next http.RoundTripper
+}
+
+// NewTransport returns a Transport that rewrites ATS requests to the server at baseURL and
+// sends every request through next, or http.DefaultTransport if it is nil.
+func NewTransport(baseURL string, next http.RoundTripper) (*Transport, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing fake ATS server URL %s: %w", baseURL, err)
+	}
+
+	if base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("error parsing fake ATS server URL %s: %w", baseURL, ErrInvalidURL)
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Transport{base: base, next: next}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	prefix, ok := Prefix(req.URL.Hostname())
+	if !ok {
+		return t.next.RoundTrip(req) //nolint:wrapcheck // the client wraps transport errors
+	}
+
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Scheme = t.base.Scheme
+	rewritten.URL.Host = t.base.Host
+	rewritten.URL.Path = strings.TrimSuffix(t.base.Path, "/") + prefix + req.URL.Path
+	rewritten.URL.RawPath = ""
+	rewritten.Host = ""
+
+	return t.next.RoundTrip(rewritten) //nolint:wrapcheck // the client wraps transport errors
+}
+
+// Prefix returns the path prefix the server serves an ATS host under, e.g. /greenhouse for
+// boards-api.greenhouse.io, /bamboo/acme for acme.bamboohr.com, /personio/acme for
+// acme.jobs.personio.de, /recruitee/acme for acme.recruitee.com, /teamtailor/acme for
+// acme.teamtailor.com or /workday/acme.wd1 for acme.wd1.myworkdayjobs.com.
+func Prefix(host string) (string, bool) {
+	host = strings.ToLower(host)
+
+	if company, ok := strings.CutSuffix(host, ".bamboohr.com"); ok && company != "" && !strings.Contains(company, ".") {
+		return "/bamboo/" + company, true
+	}
+
+	for _, suffix := range []string{".jobs.personio.de", ".jobs.personio.com"} {
+		if company, ok := strings.CutSuffix(host, suffix); ok && company != "" && !strings.Contains(company, ".") {
+			return "/personio/" + company, true
+		}
+	}
+
+	if company, ok := strings.CutSuffix(host, ".recruitee.com"); ok && company != "" && !strings.Contains(company, ".") {
+		return "/recruitee/" + company, true
+	}
+
+	// api.teamtailor.com is the JSON:API, which the server doesn't fake
+	if company, ok := strings.CutSuffix(host, ".teamtailor.com"); ok && company != "" && company != "api" && !strings.Contains(company, ".") {
+		return "/teamtailor/" + company, true
+	}
+
+	if site, ok := strings.CutSuffix(host, ".myworkdayjobs.com"); ok && strings.Count(site, ".") == 1 && !strings.HasPrefix(site, ".") {
+		return "/workday/" + site, true
+	}
+
+	prefix, ok := prefixes[host]
+
+	return prefix, ok
+}
@@ -0,0 +1,58 @@
+package atsfake
+
+import (
+	"net/http"
+	"time"
+)
+
+func (s *Server) workableJobs(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	results := make([]map[string]any, 0, len(company.Jobs))
+	for _, job := range company.Jobs {
+		results = append(results, workableJob(job))
+	}
+
+	writeJSON(w, r, map[string]any{
+		"total":   len(results),
+		"results": results,
+	})
+}
+
+func (s *Server) workableJob(w http.ResponseWriter, r *http.Request) {
+	_, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	posting := workableJob(job)
+	posting["description"] = job.Description
+
+	writeJSON(w, r, posting)
+}
+
+func workableJob(job *Job) map[string]any {
+	location := map[string]any{
+		"city":    job.City,
+		"region":  job.Region,
+		"country": job.Country,
+	}
+
+	return map[string]any{
+		"id":         "w" + job.ID,
+		"shortcode":  job.ID,
+		"title":      job.Title,
+		"remote":     job.Workplace == "remote",
+		"location":   location,
+		"locations":  []map[string]any{location},
+		"published":  job.PostedAt.Format(time.RFC3339),
+		"type":       job.EmploymentType,
+		"department": []string{job.Department},
+		"workplace":  job.workplace("remote", "hybrid", "on_site", ""),
+	}
+}
@@ -0,0 +1,106 @@
+package atsfake
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// workdayTimeTypes maps fixture employment types to Workday's time types.
+var workdayTimeTypes = map[string]string{
+	"full_time": "Full time",
+	"part_time": "Part time",
+}
+
+func (s *Server) workdayJobs(w http.ResponseWriter, r *http.Request) {
+	company, _, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	var search struct {
+		Limit  int `json:"limit"`
+		Offset int `json:"offset"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&search)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	jobs := company.Jobs[min(search.Offset, len(company.Jobs)):]
+	if search.Limit > 0 {
+		jobs = jobs[:min(search.Limit, len(jobs))]
+	}
+
+	postings := make([]map[string]any, 0, len(jobs))
+	for _, job := range jobs {
+		postings = append(postings, map[string]any{
+			"title":         job.Title,
+			"externalPath":  "/job/" + workdayJobPath(job),
+			"locationsText": job.Location,
+			"postedOn":      "Posted 30+ Days Ago",
+			"bulletFields":  []string{"R" + job.ID},
+		})
+	}
+
+	// like Workday, only the first page carries the total
+	total := 0
+	if search.Offset == 0 {
+		total = len(company.Jobs)
+	}
+
+	writeJSON(w, r, map[string]any{
+		"total":       total,
+		"jobPostings": postings,
+	})
+}
+
+func (s *Server) workdayJob(w http.ResponseWriter, r *http.Request) {
+	// the job ID follows the last underscore of the posting's path
+	path := r.PathValue("path")
+	r.SetPathValue("id", path[strings.LastIndex(path, "_")+1:])
+
+	company, job, ok := s.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	timeType, ok := workdayTimeTypes[job.EmploymentType]
+	if !ok {
+		timeType = job.EmploymentType
+	}
+
+	writeJSON(w, r, map[string]any{
+		"jobPostingInfo": map[string]any{
+			"id":             "wd" + job.ID,
+			"title":          job.Title,
+			"jobDescription": job.Description,
+			"location":       job.Location,
+			"postedOn":       "Posted 30+ Days Ago",
+			"startDate":      job.PostedAt.Format("2006-01-02"),
+			"timeType":       timeType,
+			"jobReqId":       "R" + job.ID,
+			"remoteType":     job.workplace("Remote", "Hybrid", "On-site", ""),
+			"externalUrl":    baseURL(r) + "/workday/" + r.PathValue("host") + "/" + r.PathValue("site") + "/job/" + workdayJobPath(job),
+			"country":        map[string]any{"descriptor": job.Country},
+		},
+		"hiringOrganization": map[string]any{
+			"name": company.Name,
+			"url":  company.Homepage,
+		},
+	})
+}
+
+// workdayJobPath returns the path of the job's posting, e.g. US/Senior-Backend-Engineer_1001.
+func workdayJobPath(job *Job) string {
+	title := strings.FieldsFunc(job.Title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return job.Country + "/" + strings.Join(title, "-") + "_" + job.ID
+}
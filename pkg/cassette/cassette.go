@@ -0,0 +1,319 @@
+// Package cassette records the HTTP traffic of a scrape to a directory and replays it
+// later, so a run can be reproduced offline and test fixtures can be refreshed from a real
+// scrape instead of being edited by hand.
+//
+// A cassette is a directory holding one JSON file per distinct request (method, URL and
+// body). Each file lists the responses to that request in the order they were received;
+// JSON response bodies are stored inline so the files stay readable.
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrNotRecorded is returned by a replaying Transport for a request the cassette has no
+// response for.
+var ErrNotRecorded = errors.New("request not recorded in cassette")
+
+// droppedHeaders are response headers that are not worth keeping or could leak session
+// state into a shared cassette. Content-Length is dropped because JSON bodies are reindented.
+var droppedHeaders = []string{"Content-Length", "Date", "Set-Cookie"}
+
+// maxSlugLength keeps file names readable; the hash suffix keeps them unique.
+const maxSlugLength = 80
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9.]+`)
+
+// Interaction is a request together with every response recorded for it.
+type Interaction struct {
+	Request   Request    `json:"request"`
+	Responses []Response `json:"responses"`
+}
+
+// Request identifies a recorded request.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response. The body is kept in JSON when it is valid JSON and in
+// Body otherwise.
+type Response struct {
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	JSON   json.RawMessage `json:"json,omitempty"`
+	Body   string          `json:"body,omitempty"`
+}
+
+// Transport is an http.RoundTripper that either records the traffic it forwards to the
+// next transport or replays a recorded cassette without touching the network. It is safe
+// for concurrent use.
+type Transport struct {
+	dir  string
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions map[string]*Interaction
+	served       map[string]int
+}
+
+// NewRecorder returns a Transport that sends requests through next, or
+// http.DefaultTransport if it is nil, and writes every response to the cassette in dir.
+// Files for requests made during this run are overwritten; other files are kept.
+func NewRecorder(dir string, next http.RoundTripper) (*Transport, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cassette directory %s: %w", dir, err)
+	}
+
+	return &Transport{
+		dir:          dir,
+		next:         next,
+		interactions: make(map[string]*Interaction),
+	}, nil
+}
+
+// NewReplayer returns a Transport that serves the cassette in fsys. Requests are matched on
+// method, URL and body; repeated requests get the recorded responses in order, and the last
+// one once they run out.
+func NewReplayer(fsys fs.FS) (*Transport, error) {
+	t := &Transport{
+		interactions: make(map[string]*Interaction),
+		served:       make(map[string]int),
+	}
+
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, fmt.Errorf("error listing cassette files: %w", err)
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette file %s: %w", file, err)
+		}
+
+		interaction := &Interaction{}
+
+		err = json.Unmarshal(data, interaction)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding cassette file %s: %w", file, err)
+		}
+
+		if len(interaction.Responses) == 0 {
+			return nil, fmt.Errorf("error decoding cassette file %s: %w", file, ErrNotRecorded)
+		}
+
+		t.interactions[key(interaction.Request)] = interaction
+	}
+
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Body:   string(body),
+	}
+
+	if t.next == nil {
+		return t.replay(req, recorded)
+	}
+
+	return t.record(req, recorded, body)
+}
+
+func (t *Transport) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	k := key(recorded)
+
+	t.mu.Lock()
+
+	interaction, ok := t.interactions[k]
+	if !ok {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, recorded.Method, recorded.URL)
+	}
+
+	i := min(t.served[k], len(interaction.Responses)-1)
+	t.served[k]++
+
+	t.mu.Unlock()
+
+	response := interaction.Responses[i]
+
+	body := []byte(response.Body)
+	if response.JSON != nil {
+		body = response.JSON
+	}
+
+	header := response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *Transport) record(req *http.Request, recorded Request, body []byte) (*http.Response, error) {
+	if body != nil {
+		// the body was consumed to record it, so send a fresh copy
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the client wraps transport errors
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading response to record: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	response := Response{
+		Status: resp.StatusCode,
+		Header: resp.Header.Clone(),
+	}
+
+	for _, name := range droppedHeaders {
+		response.Header.Del(name)
+	}
+
+	indented := &bytes.Buffer{}
+	if len(data) > 0 && json.Indent(indented, data, "", "  ") == nil {
+		response.JSON = indented.Bytes()
+	} else {
+		response.Body = string(data)
+	}
+
+	err = t.save(recorded, response)
+	if err != nil {
+		slog.ErrorContext(req.Context(), "Error recording HTTP response", slog.String("url", recorded.URL), slog.Any("error", err))
+	}
+
+	return resp, nil
+}
+
+// save appends response to the interaction for request and rewrites its file, so the
+// cassette is usable even if the run is interrupted.
+func (t *Transport) save(request Request, response Response) error {
+	k := key(request)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	interaction, ok := t.interactions[k]
+	if !ok {
+		interaction = &Interaction{Request: request}
+		t.interactions[k] = interaction
+	}
+
+	interaction.Responses = append(interaction.Responses, response)
+
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding cassette entry: %w", err)
+	}
+
+	path := filepath.Join(t.dir, fileName(request))
+
+	err = os.WriteFile(path, append(data, '\n'), 0o600)
+	if err != nil {
+		return fmt.Errorf("error writing cassette file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// requestBody returns a copy of the request body without consuming the original when the
+// request can be rewound.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body := req.Body
+
+	if req.GetBody != nil {
+		var err error
+
+		body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("error rewinding request body: %w", err)
+		}
+	}
+
+	defer func() {
+		_ = body.Close()
+	}()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	return data, nil
+}
+
+func key(request Request) string {
+	return request.Method + " " + request.URL + "\n" + request.Body
+}
+
+// fileName turns a request into a readable, unique file name such as
+// GET-boards-api.greenhouse.io-v1-boards-acme-jobs-1a2b3c4d.json.
+func fileName(request Request) string {
+	sum := sha256.Sum256([]byte(key(request)))
+
+	slug := request.URL
+	slug = strings.TrimPrefix(slug, "https://")
+	slug = strings.TrimPrefix(slug, "http://")
+	slug, _, _ = strings.Cut(slug, "?")
+	slug = strings.Trim(unsafeChars.ReplaceAllString(slug, "-"), "-")
+
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+	}
+
+	return request.Method + "-" + slug + "-" + hex.EncodeToString(sum[:4]) + ".json"
+}
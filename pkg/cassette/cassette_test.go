@@ -0,0 +1,134 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func get(t *testing.T, c *http.Client, method, url, body string) (int, string, error) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("io.ReadAll() error = %v", err)
+	}
+
+	return resp.StatusCode, string(data), nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/jobs" && calls.Add(1) == 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/jobs":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"jobs":[{"id":"1"}]}`))
+		case r.URL.Path == "/search":
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write([]byte("<p>" + string(body) + "</p>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	dir := t.TempDir()
+
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+
+	recording := &http.Client{Transport: recorder}
+
+	for _, want := range []int{http.StatusTooManyRequests, http.StatusOK} {
+		status, _, err := get(t, recording, http.MethodGet, server.URL+"/jobs", "")
+		if err != nil || status != want {
+			t.Fatalf("recording GET /jobs = %v, %v, want %v", status, err, want)
+		}
+	}
+
+	for _, query := range []string{"a", "b"} {
+		_, _, err = get(t, recording, http.MethodPost, server.URL+"/search", query)
+		if err != nil {
+			t.Fatalf("recording POST /search error = %v", err)
+		}
+	}
+
+	server.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 3 {
+		t.Fatalf("recorded %d files, want 3 (%v)", len(files), err)
+	}
+
+	replayer, err := NewReplayer(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("NewReplayer() error = %v", err)
+	}
+
+	replaying := &http.Client{Transport: replayer}
+
+	// responses come back in the order they were recorded, then the last one repeats
+	for _, want := range []int{http.StatusTooManyRequests, http.StatusOK, http.StatusOK} {
+		status, body, err := get(t, replaying, http.MethodGet, server.URL+"/jobs", "")
+		if err != nil || status != want {
+			t.Fatalf("replaying GET /jobs = %v, %v, want %v", status, err, want)
+		}
+
+		if want == http.StatusOK && !strings.Contains(body, `"id": "1"`) {
+			t.Errorf("replaying GET /jobs body = %s, want the recorded jobs", body)
+		}
+	}
+
+	// requests are told apart by their body
+	_, body, err := get(t, replaying, http.MethodPost, server.URL+"/search", "b")
+	if err != nil || body != "<p>b</p>" {
+		t.Errorf("replaying POST /search = %q, %v, want <p>b</p>", body, err)
+	}
+
+	_, _, err = get(t, replaying, http.MethodGet, server.URL+"/missing", "")
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("replaying an unknown request error = %v, want %v", err, ErrNotRecorded)
+	}
+}
+
+func Test_fileName(t *testing.T) {
+	t.Parallel()
+
+	name := fileName(Request{Method: http.MethodGet, URL: "https://boards-api.greenhouse.io/v1/boards/acme/jobs?content=true"})
+	if !strings.HasPrefix(name, "GET-boards-api.greenhouse.io-v1-boards-acme-jobs-") || !strings.HasSuffix(name, ".json") {
+		t.Errorf("fileName() = %v, want GET-boards-api.greenhouse.io-v1-boards-acme-jobs-<hash>.json", name)
+	}
+
+	other := fileName(Request{Method: http.MethodGet, URL: "https://boards-api.greenhouse.io/v1/boards/acme/jobs"})
+	if name == other {
+		t.Errorf("fileName() = %v for different queries, want different names", name)
+	}
+}
@@ -6,13 +6,34 @@ package db
 
 import (
 	"database/sql"
+	"time"
 )
 
+type CompanyInfo struct {
+	Source    string      `json:"source"`
+	Company   string      `json:"company"`
+	Data      interface{} `json:"data"`
+	FetchedAt time.Time   `json:"fetched_at"`
+}
+
 type Job struct {
 	ID          int64          `json:"id"`
 	AbsoluteUrl string         `json:"absolute_url"`
 	Data        interface{}    `json:"data"`
 	Source      sql.NullString `json:"source"`
+	SourceID    sql.NullString `json:"source_id"`
+	Company     sql.NullString `json:"company"`
+	FirstSeenAt sql.NullTime   `json:"first_seen_at"`
+	LastSeenAt  sql.NullTime   `json:"last_seen_at"`
+	ClosedAt    sql.NullTime   `json:"closed_at"`
+}
+
+type JobRevision struct {
+	ID        int64       `json:"id"`
+	JobID     int64       `json:"job_id"`
+	Data      interface{} `json:"data"`
+	Changes   interface{} `json:"changes"`
+	CreatedAt time.Time   `json:"created_at"`
 }
 
 type SchemaMigration struct {
@@ -9,9 +9,18 @@ import (
 )
 
 type Querier interface {
+	CloseJob(ctx context.Context, arg CloseJobParams) (int64, error)
+	CloseMissingJobs(ctx context.Context, arg CloseMissingJobsParams) ([]Job, error)
 	CreateJob(ctx context.Context, arg CreateJobParams) (Job, error)
+	CreateJobRevision(ctx context.Context, arg CreateJobRevisionParams) (JobRevision, error)
+	GetCompanyInfo(ctx context.Context, arg GetCompanyInfoParams) (CompanyInfo, error)
 	GetJob(ctx context.Context, id int64) (Job, error)
+	GetJobBySource(ctx context.Context, arg GetJobBySourceParams) (Job, error)
+	ListCompanyJobs(ctx context.Context, arg ListCompanyJobsParams) ([]Job, error)
+	ListJobRevisions(ctx context.Context, jobID int64) ([]JobRevision, error)
 	ListJobs(ctx context.Context) ([]Job, error)
+	UpsertCompanyInfo(ctx context.Context, arg UpsertCompanyInfoParams) error
+	UpsertJob(ctx context.Context, arg UpsertJobParams) (Job, error)
 }
 
 var _ Querier = (*Queries)(nil)
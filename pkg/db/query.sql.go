@@ -7,12 +7,83 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const closeJob = `-- name: CloseJob :execrows
+UPDATE jobs SET closed_at = ?1
+WHERE source = ?2
+    AND source_id = ?3
+    AND closed_at IS NULL
+`
+
+type CloseJobParams struct {
+	ClosedAt sql.NullTime   `json:"closed_at"`
+	Source   sql.NullString `json:"source"`
+	SourceID sql.NullString `json:"source_id"`
+}
+
+func (q *Queries) CloseJob(ctx context.Context, arg CloseJobParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, closeJob, arg.ClosedAt, arg.Source, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const closeMissingJobs = `-- name: CloseMissingJobs :many
+UPDATE jobs SET closed_at = ?1
+WHERE source = ?2
+    AND company = ?3
+    AND closed_at IS NULL
+    AND last_seen_at < ?1
+RETURNING id, absolute_url, data, source, source_id, company, first_seen_at, last_seen_at, closed_at
+`
+
+type CloseMissingJobsParams struct {
+	ClosedAt sql.NullTime   `json:"closed_at"`
+	Source   sql.NullString `json:"source"`
+	Company  sql.NullString `json:"company"`
+}
+
+func (q *Queries) CloseMissingJobs(ctx context.Context, arg CloseMissingJobsParams) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, closeMissingJobs, arg.ClosedAt, arg.Source, arg.Company)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.AbsoluteUrl,
+			&i.Data,
+			&i.Source,
+			&i.SourceID,
+			&i.Company,
+			&i.FirstSeenAt,
+			&i.LastSeenAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createJob = `-- name: CreateJob :one
 INSERT INTO jobs (absolute_url, data)
 VALUES (?, ?)
-RETURNING id, absolute_url, data, source
+RETURNING id, absolute_url, data, source, source_id, company, first_seen_at, last_seen_at, closed_at
 `
 
 type CreateJobParams struct {
@@ -28,12 +99,70 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 		&i.AbsoluteUrl,
 		&i.Data,
 		&i.Source,
+		&i.SourceID,
+		&i.Company,
+		&i.FirstSeenAt,
+		&i.LastSeenAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const createJobRevision = `-- name: CreateJobRevision :one
+INSERT INTO job_revisions (job_id, data, changes, created_at)
+VALUES (?, ?, ?, ?)
+RETURNING id, job_id, data, changes, created_at
+`
+
+type CreateJobRevisionParams struct {
+	JobID     int64       `json:"job_id"`
+	Data      interface{} `json:"data"`
+	Changes   interface{} `json:"changes"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func (q *Queries) CreateJobRevision(ctx context.Context, arg CreateJobRevisionParams) (JobRevision, error) {
+	row := q.db.QueryRowContext(ctx, createJobRevision,
+		arg.JobID,
+		arg.Data,
+		arg.Changes,
+		arg.CreatedAt,
+	)
+	var i JobRevision
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.Data,
+		&i.Changes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCompanyInfo = `-- name: GetCompanyInfo :one
+SELECT source, company, data, fetched_at FROM company_info
+WHERE source = ? AND company = ? LIMIT 1
+`
+
+type GetCompanyInfoParams struct {
+	Source  string `json:"source"`
+	Company string `json:"company"`
+}
+
+func (q *Queries) GetCompanyInfo(ctx context.Context, arg GetCompanyInfoParams) (CompanyInfo, error) {
+	row := q.db.QueryRowContext(ctx, getCompanyInfo, arg.Source, arg.Company)
+	var i CompanyInfo
+	err := row.Scan(
+		&i.Source,
+		&i.Company,
+		&i.Data,
+		&i.FetchedAt,
 	)
 	return i, err
 }
 
 const getJob = `-- name: GetJob :one
-SELECT id, absolute_url, data, source FROM jobs
+SELECT id, absolute_url, data, source, source_id, company, first_seen_at, last_seen_at, closed_at FROM jobs
 WHERE id = ? LIMIT 1
 `
 
@@ -45,12 +174,123 @@ func (q *Queries) GetJob(ctx context.Context, id int64) (Job, error) {
 		&i.AbsoluteUrl,
 		&i.Data,
 		&i.Source,
+		&i.SourceID,
+		&i.Company,
+		&i.FirstSeenAt,
+		&i.LastSeenAt,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
+const getJobBySource = `-- name: GetJobBySource :one
+SELECT id, absolute_url, data, source, source_id, company, first_seen_at, last_seen_at, closed_at FROM jobs
+WHERE source = ? AND source_id = ? LIMIT 1
+`
+
+type GetJobBySourceParams struct {
+	Source   sql.NullString `json:"source"`
+	SourceID sql.NullString `json:"source_id"`
+}
+
+func (q *Queries) GetJobBySource(ctx context.Context, arg GetJobBySourceParams) (Job, error) {
+	row := q.db.QueryRowContext(ctx, getJobBySource, arg.Source, arg.SourceID)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.AbsoluteUrl,
+		&i.Data,
+		&i.Source,
+		&i.SourceID,
+		&i.Company,
+		&i.FirstSeenAt,
+		&i.LastSeenAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const listCompanyJobs = `-- name: ListCompanyJobs :many
+SELECT id, absolute_url, data, source, source_id, company, first_seen_at, last_seen_at, closed_at FROM jobs
+WHERE source = ? AND company = ?
+ORDER BY id
+`
+
+type ListCompanyJobsParams struct {
+	Source  sql.NullString `json:"source"`
+	Company sql.NullString `json:"company"`
+}
+
+func (q *Queries) ListCompanyJobs(ctx context.Context, arg ListCompanyJobsParams) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, listCompanyJobs, arg.Source, arg.Company)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.AbsoluteUrl,
+			&i.Data,
+			&i.Source,
+			&i.SourceID,
+			&i.Company,
+			&i.FirstSeenAt,
+			&i.LastSeenAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobRevisions = `-- name: ListJobRevisions :many
+SELECT id, job_id, data, changes, created_at FROM job_revisions
+WHERE job_id = ?
+ORDER BY id
+`
+
+func (q *Queries) ListJobRevisions(ctx context.Context, jobID int64) ([]JobRevision, error) {
+	rows, err := q.db.QueryContext(ctx, listJobRevisions, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobRevision
+	for rows.Next() {
+		var i JobRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobID,
+			&i.Data,
+			&i.Changes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listJobs = `-- name: ListJobs :many
-SELECT id, absolute_url, data, source FROM jobs
+SELECT id, absolute_url, data, source, source_id, company, first_seen_at, last_seen_at, closed_at FROM jobs
 ORDER BY id
 `
 
@@ -68,6 +308,11 @@ func (q *Queries) ListJobs(ctx context.Context) ([]Job, error) {
 			&i.AbsoluteUrl,
 			&i.Data,
 			&i.Source,
+			&i.SourceID,
+			&i.Company,
+			&i.FirstSeenAt,
+			&i.LastSeenAt,
+			&i.ClosedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -81,3 +326,69 @@ func (q *Queries) ListJobs(ctx context.Context) ([]Job, error) {
 	}
 	return items, nil
 }
+
+const upsertCompanyInfo = `-- name: UpsertCompanyInfo :exec
+INSERT INTO company_info (source, company, data, fetched_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (source, company) DO UPDATE SET
+    data = excluded.data,
+    fetched_at = excluded.fetched_at
+`
+
+type UpsertCompanyInfoParams struct {
+	Source    string      `json:"source"`
+	Company   string      `json:"company"`
+	Data      interface{} `json:"data"`
+	FetchedAt time.Time   `json:"fetched_at"`
+}
+
+func (q *Queries) UpsertCompanyInfo(ctx context.Context, arg UpsertCompanyInfoParams) error {
+	_, err := q.db.ExecContext(ctx, upsertCompanyInfo,
+		arg.Source,
+		arg.Company,
+		arg.Data,
+		arg.FetchedAt,
+	)
+	return err
+}
+
+const upsertJob = `-- name: UpsertJob :one
+INSERT INTO jobs (absolute_url, data, company, first_seen_at, last_seen_at)
+VALUES (?1, ?2, ?3, ?4, ?4)
+ON CONFLICT (source, source_id) DO UPDATE SET
+    absolute_url = excluded.absolute_url,
+    data = excluded.data,
+    company = excluded.company,
+    last_seen_at = excluded.last_seen_at,
+    closed_at = NULL
+RETURNING id, absolute_url, data, source, source_id, company, first_seen_at, last_seen_at, closed_at
+`
+
+type UpsertJobParams struct {
+	AbsoluteUrl string         `json:"absolute_url"`
+	Data        interface{}    `json:"data"`
+	Company     sql.NullString `json:"company"`
+	SeenAt      sql.NullTime   `json:"seen_at"`
+}
+
+func (q *Queries) UpsertJob(ctx context.Context, arg UpsertJobParams) (Job, error) {
+	row := q.db.QueryRowContext(ctx, upsertJob,
+		arg.AbsoluteUrl,
+		arg.Data,
+		arg.Company,
+		arg.SeenAt,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.AbsoluteUrl,
+		&i.Data,
+		&i.Source,
+		&i.SourceID,
+		&i.Company,
+		&i.FirstSeenAt,
+		&i.LastSeenAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,104 @@
+package helpers
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// DefaultConcurrency is the number of concurrent requests a loader makes when fetching
+// individual jobs, unless overridden with WithConcurrency.
+const DefaultConcurrency = 8
+
+type concurrencyKey struct{}
+
+// WithConcurrency returns a context that tells loaders to fetch up to n jobs at a time.
+// Values below 1 are treated as 1.
+func WithConcurrency(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, concurrencyKey{}, max(n, 1))
+}
+
+// Concurrency returns the worker count set with WithConcurrency, or DefaultConcurrency.
+func Concurrency(ctx context.Context) int {
+	n, ok := ctx.Value(concurrencyKey{}).(int)
+	if !ok {
+		return DefaultConcurrency
+	}
+
+	return n
+}
+
+// FetchAll calls fetch for every item using at most Concurrency(ctx) goroutines. Results and
+// errors are returned in the same order as items. Once ctx is done, items that have not
+// started yet are skipped and their error is set to ctx.Err().
+func FetchAll[T, R any](ctx context.Context, items []T, fetch func(context.Context, T) (R, error)) ([]R, []error) {
+	results := make([]R, 0, len(items))
+	errs := make([]error, 0, len(items))
+
+	for result, err := range FetchEach(ctx, items, fetch) {
+		results = append(results, result)
+		errs = append(errs, err)
+	}
+
+	return results, errs
+}
+
+// FetchEach is the streaming form of FetchAll. It yields each result in the same order as
+// items as soon as it and every result before it are ready, so callers can process jobs
+// while later ones are still being fetched. Breaking out of the loop cancels the fetches
+// that are still running and waits for them to return.
+func FetchEach[T, R any](ctx context.Context, items []T, fetch func(context.Context, T) (R, error)) iter.Seq2[R, error] {
+	type outcome struct {
+		result R
+		err    error
+	}
+
+	return func(yield func(R, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		// each item gets its own buffered channel so workers never wait on the consumer
+		outcomes := make([]chan outcome, len(items))
+		for i := range outcomes {
+			outcomes[i] = make(chan outcome, 1)
+		}
+
+		indexes := make(chan int)
+
+		var wg sync.WaitGroup
+
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+
+		for range min(Concurrency(ctx), len(items)) {
+			wg.Go(func() {
+				for i := range indexes {
+					if err := ctx.Err(); err != nil {
+						outcomes[i] <- outcome{err: err}
+						continue
+					}
+
+					result, err := fetch(ctx, items[i])
+					outcomes[i] <- outcome{result: result, err: err}
+				}
+			})
+		}
+
+		wg.Go(func() {
+			// every index is handed out, even after cancellation, so each channel gets a value
+			for i := range items {
+				indexes <- i
+			}
+
+			close(indexes)
+		})
+
+		for i := range items {
+			o := <-outcomes[i]
+			if !yield(o.result, o.err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errOdd = errors.New("odd")
+
+func TestFetchAllPreservesOrder(t *testing.T) {
+	t.Parallel()
+
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	var running, peak atomic.Int32
+
+	ctx := WithConcurrency(t.Context(), 4)
+
+	results, errs := FetchAll(ctx, items, func(_ context.Context, i int) (string, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+
+		// finish out of order
+		time.Sleep(time.Duration(len(items)-i) * 50 * time.Microsecond)
+
+		if i%2 == 1 {
+			return "", errOdd
+		}
+
+		return strconv.Itoa(i), nil
+	})
+
+	for i := range items {
+		if i%2 == 1 {
+			if !errors.Is(errs[i], errOdd) {
+				t.Errorf("FetchAll() errs[%d] = %v, want %v", i, errs[i], errOdd)
+			}
+
+			continue
+		}
+
+		if errs[i] != nil || results[i] != strconv.Itoa(i) {
+			t.Errorf("FetchAll()[%d] = %q, %v, want %q, nil", i, results[i], errs[i], strconv.Itoa(i))
+		}
+	}
+
+	if peak.Load() > 4 {
+		t.Errorf("FetchAll() ran %d fetches at once, want at most 4", peak.Load())
+	}
+}
+
+func TestFetchAllCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(WithConcurrency(t.Context(), 1))
+
+	var calls atomic.Int32
+
+	_, errs := FetchAll(ctx, []int{1, 2, 3}, func(_ context.Context, _ int) (int, error) {
+		calls.Add(1)
+		cancel()
+
+		return 0, nil
+	})
+
+	if calls.Load() != 1 {
+		t.Errorf("FetchAll() made %d calls after cancellation, want 1", calls.Load())
+	}
+
+	if !errors.Is(errs[2], context.Canceled) {
+		t.Errorf("FetchAll() errs[2] = %v, want %v", errs[2], context.Canceled)
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	t.Parallel()
+
+	if got := Concurrency(t.Context()); got != DefaultConcurrency {
+		t.Errorf("Concurrency() = %v, want %v", got, DefaultConcurrency)
+	}
+
+	if got := Concurrency(WithConcurrency(t.Context(), 0)); got != 1 {
+		t.Errorf("Concurrency() = %v, want 1", got)
+	}
+}
+
+func TestFetchEachStopsEarly(t *testing.T) {
+	t.Parallel()
+
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i
+	}
+
+	var started, cancelled atomic.Int32
+
+	ctx := WithConcurrency(t.Context(), 2)
+
+	got := make([]int, 0)
+
+	for result, err := range FetchEach(ctx, items, func(ctx context.Context, i int) (int, error) {
+		started.Add(1)
+
+		if i == 0 {
+			return i, nil
+		}
+
+		// block until the consumer breaks out of the loop
+		<-ctx.Done()
+		cancelled.Add(1)
+
+		return 0, ctx.Err()
+	}) {
+		if err != nil {
+			t.Fatalf("FetchEach() error = %v, want nil", err)
+		}
+
+		got = append(got, result)
+
+		break
+	}
+
+	if len(got) != 1 || got[0] != 0 {
+		t.Errorf("FetchEach() yielded %v, want [0]", got)
+	}
+
+	if started.Load() > 3 {
+		t.Errorf("FetchEach() started %d fetches, want at most 3", started.Load())
+	}
+
+	if cancelled.Load() != started.Load()-1 {
+		t.Errorf("FetchEach() returned with %d fetches still running", started.Load()-1-cancelled.Load())
+	}
+}
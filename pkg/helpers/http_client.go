@@ -1,16 +1,23 @@
 package helpers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/net/html"
+	"golang.org/x/time/rate"
 )
 
 var (
@@ -18,7 +25,7 @@ var (
 	ErrNonOKStatusCode = errors.New("received non-OK status code")
 	// ErrNoLDJSONFound is returned when no LD+JSON script tags are found in the HTML.
 	ErrNoLDJSONFound = errors.New("no LD+JSON script tags found")
-	client           = &http.Client{}
+	client           = NewClient(nil)
 	defaultHeaders   = map[string]string{
 		"Accept":          "*/*",
 		"Accept-Language": "en-US,en;q=0.9",
@@ -27,52 +34,107 @@ var (
 	}
 )
 
-// PostJSON performs an HTTP POST request with a JSON payload and returns the response body.
-func PostJSON(ctx context.Context, url string, payload io.Reader, headers map[string]string) ([]byte, error) {
-	slog.DebugContext(ctx, "POST JSON", slog.String("url", url))
+// DefaultClientConfig is used for every ATS that has no config of its own.
+var DefaultClientConfig = ClientConfig{
+	Timeout:    30 * time.Second,
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, payload)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create HTTP request", slog.String("url", url), slog.Any("error", err))
+// ClientConfig controls timeouts and retries for the requests made on behalf of an ATS.
+type ClientConfig struct {
+	// Timeout bounds a single attempt, including reading the response body. Zero disables it.
+	Timeout time.Duration
+	// MaxRetries is how many times a request is retried after a 429, a 5xx or a transport error.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry. It doubles for every further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, including delays requested through Retry-After.
+	MaxDelay time.Duration
+}
 
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+// Client performs HTTP requests with per-attempt timeouts, retries with exponential
+// backoff and jitter, and per-host rate limiting. Settings are looked up by the ATS set on
+// the request context with WithATS.
+type Client struct {
+	httpClient *http.Client
+	config     ClientConfig
+	atsConfig  map[string]ClientConfig
+	sleep      func(ctx context.Context, d time.Duration) error
+
+	rateLimit     RateLimit
+	atsRateLimits map[string]RateLimit
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithClientConfig sets the config used for every ATS without a config of its own.
+func WithClientConfig(config ClientConfig) ClientOption {
+	return func(c *Client) {
+		c.config = config
 	}
+}
 
-	if headers == nil {
-		headers = defaultHeaders
+// WithATSConfig sets the config used for requests made on behalf of the named ATS.
+func WithATSConfig(ats string, config ClientConfig) ClientOption {
+	return func(c *Client) {
+		c.atsConfig[ats] = config
 	}
+}
 
-	for key, value := range headers {
-		req.Header.Set(key, value)
+// NewClient returns a Client that sends requests through httpClient, or a default
+// http.Client if it is nil.
+func NewClient(httpClient *http.Client, opts ...ClientOption) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
 	}
 
-	// Let's do it!
-	resp, err := client.Do(req)
-	if err != nil {
-		// oh no
-		slog.ErrorContext(ctx, "Failed to perform HTTP request", slog.String("url", url), slog.Any("error", err))
-		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
+	c := &Client{
+		httpClient:    httpClient,
+		config:        DefaultClientConfig,
+		atsConfig:     make(map[string]ClientConfig),
+		sleep:         sleep,
+		rateLimit:     DefaultRateLimit,
+		atsRateLimits: maps.Clone(DefaultATSRateLimits),
+		limiters:      make(map[string]*rate.Limiter),
 	}
 
-	defer func() {
-		closeErr := resp.Body.Close()
-		if closeErr != nil {
-			slog.ErrorContext(ctx, "Error closing response body", slog.String("url", url), slog.Any("error", closeErr))
-		}
-	}()
+	for _, opt := range opts {
+		opt(c)
+	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrNonOKStatusCode, resp.StatusCode)
+	return c
+}
+
+// Config returns the config used for requests made on behalf of the named ATS.
+func (c *Client) Config(ats string) ClientConfig {
+	config, ok := c.atsConfig[ats]
+	if !ok {
+		return c.config
 	}
 
-	return io.ReadAll(resp.Body) //nolint:wrapcheck // we want to return the original error
+	return config
 }
 
-// GetJSON performs an HTTP GET request and returns the response body.
-func GetJSON(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
-	slog.DebugContext(ctx, "GET JSON", slog.String("url", url))
+// Do sends a request and returns the body of a 200 OK response. Any other status is
+// returned as an *HTTPError. Requests that fail with a 429, a 5xx or a transport error are
+// retried according to the ATS config; a Retry-After header on the response overrides the
+// backoff when it asks for a longer wait.
+func (c *Client) Do(ctx context.Context, method, url string, body []byte, headers map[string]string) ([]byte, error) {
+	ats := ATS(ctx)
+	config := c.Config(ats)
+
+	var payload io.Reader
+	if body != nil {
+		payload = bytes.NewReader(body)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to create HTTP request", slog.String("url", url), slog.Any("error", err))
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
@@ -86,9 +148,79 @@ func GetJSON(ctx context.Context, url string, headers map[string]string) ([]byte
 		req.Header.Set(key, value)
 	}
 
+	for attempt := 0; ; attempt++ {
+		err = c.wait(ctx, ats, url)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := c.attempt(ctx, config, req)
+		if err == nil {
+			return data, nil
+		}
+
+		if attempt >= config.MaxRetries || !retryable(ctx, err) {
+			return nil, err
+		}
+
+		var header http.Header
+
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) {
+			header = httpErr.Header
+		}
+
+		delay := backoff(config, attempt, retryAfter(header))
+
+		slog.WarnContext(ctx, "Retrying HTTP request",
+			slog.String("url", url),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("delay", delay),
+			slog.Any("error", err),
+		)
+
+		err = c.sleep(ctx, delay)
+		if err != nil {
+			return nil, fmt.Errorf("error waiting to retry HTTP request: %w", err)
+		}
+	}
+}
+
+// retryable reports whether a failed attempt is worth retrying.
+func retryable(ctx context.Context, err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Temporary()
+	}
+
+	// transport error; only retry if the caller hasn't given up
+	return ctx.Err() == nil
+}
+
+func (c *Client) attempt(ctx context.Context, config ClientConfig, original *http.Request) ([]byte, error) {
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	url := original.URL.String()
+	req := original.Clone(ctx)
+
+	if original.GetBody != nil {
+		body, err := original.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind HTTP request body: %w", err)
+		}
+
+		req.Body = body
+	}
+
 	// Let's do it!
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		// oh no
 		slog.ErrorContext(ctx, "Failed to perform HTTP request", slog.String("url", url), slog.Any("error", err))
 		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
 	}
@@ -102,46 +234,117 @@ func GetJSON(ctx context.Context, url string, headers map[string]string) ([]byte
 
 	if resp.StatusCode != http.StatusOK {
 		slog.ErrorContext(ctx, "Received non-OK HTTP status", slog.String("url", url), slog.Int("status_code", resp.StatusCode))
-		return nil, fmt.Errorf("%w: %d", ErrNonOKStatusCode, resp.StatusCode)
+		return nil, newHTTPError(ctx, req, resp)
 	}
 
-	return io.ReadAll(resp.Body) //nolint:wrapcheck // we want to return the original error
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		// a body cut short is a transport error, so it is retried like one
+		return nil, fmt.Errorf("failed to read HTTP response body: %w", err)
+	}
+
+	return data, nil
 }
 
-// GetLDJSON fetches a URL and extracts the LD+JSON structured data from it.
-func GetLDJSON(ctx context.Context, url string) (map[string]any, error) {
-	result := make(map[string]any)
+// backoff returns the delay before retry number attempt+1: an exponential backoff with
+// equal jitter, or the Retry-After delay if that is longer, capped at MaxDelay.
+func backoff(config ClientConfig, attempt int, retryAfter time.Duration) time.Duration {
+	delay := config.BaseDelay << min(attempt, 30) //nolint:mnd // avoid overflowing the shift
+	if delay <= 0 || delay > config.MaxDelay {
+		delay = config.MaxDelay
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create HTTP request", slog.String("url", url), slog.Any("error", err))
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	if half := delay / 2; half > 0 {
+		delay = half + rand.N(half+1) //nolint:gosec // jitter doesn't need a secure source
 	}
 
-	for key, value := range defaultHeaders {
-		req.Header.Set(key, value)
+	return min(max(delay, retryAfter), config.MaxDelay)
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
 	}
 
-	// Let's do it!
-	resp, err := client.Do(req)
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	at, err := http.ParseTime(value)
+	if err == nil {
+		return max(time.Until(at), 0)
+	}
+
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck // callers wrap it
+	case <-timer.C:
+		return nil
+	}
+}
+
+type atsKey struct{}
+
+// WithATS returns a context that marks requests as made on behalf of the named ATS, so the
+// client applies that ATS's config.
+func WithATS(ctx context.Context, ats string) context.Context {
+	return context.WithValue(ctx, atsKey{}, ats)
+}
+
+// ATS returns the ATS set with WithATS, or an empty string.
+func ATS(ctx context.Context) string {
+	ats, _ := ctx.Value(atsKey{}).(string)
+	return ats
+}
+
+// PostJSON performs an HTTP POST request with a JSON payload and returns the response body.
+func PostJSON(ctx context.Context, url string, payload io.Reader, headers map[string]string) ([]byte, error) {
+	slog.DebugContext(ctx, "POST JSON", slog.String("url", url))
+
+	// the payload is buffered so it can be sent again on retries
+	body, err := io.ReadAll(payload)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to perform HTTP request", slog.String("url", url), slog.Any("error", err))
-		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
+		return nil, fmt.Errorf("failed to read request payload: %w", err)
 	}
 
-	defer func() {
-		closeErr := resp.Body.Close()
-		if closeErr != nil {
-			slog.ErrorContext(ctx, "Error closing response body", slog.String("url", url), slog.Any("error", closeErr))
-		}
-	}()
+	return client.Do(ctx, http.MethodPost, url, body, headers)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		slog.ErrorContext(ctx, "Received non-OK HTTP status", slog.String("url", url), slog.Int("status_code", resp.StatusCode))
-		return nil, fmt.Errorf("%w: %d", ErrNonOKStatusCode, resp.StatusCode)
+// GetJSON performs an HTTP GET request and returns the response body.
+func GetJSON(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	slog.DebugContext(ctx, "GET JSON", slog.String("url", url))
+
+	return client.Do(ctx, http.MethodGet, url, nil, headers)
+}
+
+// GetHTML performs an HTTP GET request for a web page, or another document such as an XML
+// job feed, and returns the response body.
+func GetHTML(ctx context.Context, url string) ([]byte, error) {
+	slog.DebugContext(ctx, "GET HTML", slog.String("url", url))
+
+	return client.Do(ctx, http.MethodGet, url, nil, defaultHeaders)
+}
+
+// GetLDJSON fetches a URL and extracts the LD+JSON structured data from it.
+func GetLDJSON(ctx context.Context, url string) (map[string]any, error) {
+	result := make(map[string]any)
+
+	body, err := client.Do(ctx, http.MethodGet, url, nil, defaultHeaders)
+	if err != nil {
+		return nil, err
 	}
 
-	doc, err := html.Parse(resp.Body)
+	doc, err := html.Parse(bytes.NewReader(body))
 	if err != nil {
 		slog.ErrorContext(ctx, "Error parsing HTML", slog.String("url", url), slog.Any("error", err))
 		return nil, fmt.Errorf("error parsing HTML: %w", err)
@@ -189,12 +392,17 @@ func GetLDJSON(ctx context.Context, url string) (map[string]any, error) {
 	return result, nil
 }
 
+// SetClient sets the client used by the package-level helpers.
+func SetClient(c *Client) {
+	client = c
+}
+
 // SetHTTPClient sets the HTTP client for testing purposes.
 func SetHTTPClient(c *http.Client) {
-	client = c
+	client = NewClient(c)
 }
 
 // ResetHTTPClient resets the HTTP client to the default client.
 func ResetHTTPClient() {
-	client = &http.Client{}
+	client = NewClient(nil)
 }
@@ -0,0 +1,175 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(opts ...ClientOption) (*Client, *[]time.Duration) {
+	delays := make([]time.Duration, 0)
+
+	c := NewClient(nil, opts...)
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	return c, &delays
+}
+
+func TestClient_DoRetries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			if r.Method == http.MethodPost && r.ContentLength != 2 {
+				t.Errorf("retried request ContentLength = %v, want 2", r.ContentLength)
+			}
+
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c, delays := newTestClient(WithClientConfig(ClientConfig{
+		MaxRetries: 3,
+		BaseDelay:  time.Second,
+		MaxDelay:   10 * time.Second,
+	}))
+
+	body, err := c.Do(t.Context(), http.MethodPost, server.URL, []byte(`{}`), nil)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if string(body) != `{}` {
+		t.Errorf("Do() body = %s, want {}", body)
+	}
+
+	if calls.Load() != 3 || len(*delays) != 2 {
+		t.Fatalf("Do() made %d calls with %d retries, want 3 calls with 2 retries", calls.Load(), len(*delays))
+	}
+
+	if d := (*delays)[0]; d < 500*time.Millisecond || d > time.Second {
+		t.Errorf("Do() first backoff = %v, want between 500ms and 1s", d)
+	}
+
+	if d := (*delays)[1]; d != 7*time.Second {
+		t.Errorf("Do() Retry-After backoff = %v, want 7s", d)
+	}
+}
+
+func TestClient_DoGivesUp(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c, _ := newTestClient(
+		WithClientConfig(ClientConfig{MaxRetries: 5}),
+		WithATSConfig("greenhouse", ClientConfig{MaxRetries: 1}),
+	)
+
+	_, err := c.Do(WithATS(t.Context(), "greenhouse"), http.MethodGet, server.URL, nil, nil)
+	if !errors.Is(err, ErrNonOKStatusCode) {
+		t.Errorf("Do() error = %v, want %v", err, ErrNonOKStatusCode)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("Do() with MaxRetries 1 made %d calls, want 2", calls.Load())
+	}
+
+	calls.Store(0)
+
+	_, err = c.Do(t.Context(), http.MethodGet, server.URL+"/missing", nil, nil)
+	if !errors.Is(err, ErrNonOKStatusCode) {
+		t.Errorf("Do() error = %v, want %v", err, ErrNonOKStatusCode)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("Do() retried a 404, made %d calls, want 1", calls.Load())
+	}
+}
+
+func TestClient_DoTimeout(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		_, _ = w.Write([]byte(`ok`))
+	}))
+	defer server.Close()
+
+	c, delays := newTestClient(WithClientConfig(ClientConfig{
+		Timeout:    20 * time.Millisecond,
+		MaxRetries: 1,
+	}))
+
+	body, err := c.Do(t.Context(), http.MethodGet, server.URL, nil, nil)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if string(body) != "ok" || len(*delays) != 1 {
+		t.Errorf("Do() = %s after %d retries, want ok after 1", body, len(*delays))
+	}
+}
+
+func Test_retryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "missing", value: "", want: 0},
+		{name: "seconds", value: "120", want: 2 * time.Minute},
+		{name: "invalid", value: "soon", want: 0},
+		{name: "past date", value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+
+			if got := retryAfter(header); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodyLength is how much of a failed response body an HTTPError keeps.
+const maxErrorBodyLength = 1024
+
+// HTTPError is returned when an ATS responds with a status other than 200 OK.
+// It matches ErrNonOKStatusCode with errors.Is.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Method and URL identify the request.
+	Method string
+	URL    string
+	// ATS is the ATS the request was made for, as set with WithATS.
+	ATS string
+	// Header holds the response headers.
+	Header http.Header
+	// Body holds the start of the response body, truncated to maxErrorBodyLength bytes.
+	Body string
+}
+
+func newHTTPError(ctx context.Context, req *http.Request, resp *http.Response) *HTTPError {
+	// the body is only informational, so a failed read just leaves it short
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		ATS:        ATS(ctx),
+		Header:     resp.Header,
+		Body:       strings.ToValidUTF8(string(body), ""),
+	}
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s: %d from %s %s", ErrNonOKStatusCode, e.StatusCode, e.Method, e.URL)
+}
+
+func (e *HTTPError) Unwrap() error {
+	return ErrNonOKStatusCode
+}
+
+// NotFound reports whether the resource doesn't exist (404 Not Found or 410 Gone).
+func (e *HTTPError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone
+}
+
+// Blocked reports whether the ATS refused to serve the request (401 or 403).
+func (e *HTTPError) Blocked() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// Temporary reports whether the request may succeed if retried (429 or 5xx).
+func (e *HTTPError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// StatusCode returns the HTTP status code of the HTTPError in err's chain, or 0 if there is none.
+func StatusCode(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+
+	return 0
+}
+
+// WrapNotFound wraps err with target when err is an HTTPError for a missing resource, so
+// loaders can report a missing board or job with their own sentinel. Other errors are
+// returned unchanged.
+func WrapNotFound(err, target error) error {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.NotFound() {
+		return fmt.Errorf("%w: %w", target, err)
+	}
+
+	return err
+}
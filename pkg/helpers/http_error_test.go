@@ -0,0 +1,63 @@
+package helpers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var errMissing = errors.New("missing")
+
+func TestHTTPError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(strings.Repeat("x", 2*maxErrorBodyLength)))
+	}))
+	defer server.Close()
+
+	c, _ := newTestClient()
+
+	_, err := c.Do(WithATS(t.Context(), "lever"), http.MethodGet, server.URL+"/board", nil, nil)
+	if !errors.Is(err, ErrNonOKStatusCode) {
+		t.Fatalf("Do() error = %v, want %v", err, ErrNonOKStatusCode)
+	}
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Do() error = %T, want *HTTPError", err)
+	}
+
+	if httpErr.StatusCode != http.StatusNotFound || httpErr.URL != server.URL+"/board" || httpErr.ATS != "lever" {
+		t.Errorf("HTTPError = %d %s %s, want 404 %s/board lever", httpErr.StatusCode, httpErr.URL, httpErr.ATS, server.URL)
+	}
+
+	if httpErr.Header.Get("X-Request-Id") != "abc" {
+		t.Errorf("HTTPError.Header = %v, want X-Request-Id", httpErr.Header)
+	}
+
+	if len(httpErr.Body) != maxErrorBodyLength {
+		t.Errorf("len(HTTPError.Body) = %d, want %d", len(httpErr.Body), maxErrorBodyLength)
+	}
+
+	if !httpErr.NotFound() || httpErr.Blocked() || httpErr.Temporary() {
+		t.Errorf("HTTPError 404 NotFound/Blocked/Temporary = %v/%v/%v, want true/false/false", httpErr.NotFound(), httpErr.Blocked(), httpErr.Temporary())
+	}
+
+	if StatusCode(err) != http.StatusNotFound {
+		t.Errorf("StatusCode() = %d, want 404", StatusCode(err))
+	}
+
+	if !errors.Is(WrapNotFound(err, errMissing), errMissing) {
+		t.Errorf("WrapNotFound() did not wrap a 404 with the target")
+	}
+
+	serverErr := &HTTPError{StatusCode: http.StatusInternalServerError}
+	if errors.Is(WrapNotFound(serverErr, errMissing), errMissing) {
+		t.Errorf("WrapNotFound() wrapped a 500 with the target")
+	}
+}
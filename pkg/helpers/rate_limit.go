@@ -0,0 +1,96 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultRateLimit applies to every ATS without a limit of its own.
+var DefaultRateLimit = RateLimit{PerSecond: 5, Burst: 10}
+
+// DefaultATSRateLimits holds the built-in limits for ATSes whose APIs are shared by many
+// companies on a single host, such as boards-api.greenhouse.io.
+var DefaultATSRateLimits = map[string]RateLimit{
+	"ashby":           {PerSecond: 2, Burst: 4},
+	"gem":             {PerSecond: 2, Burst: 4},
+	"greenhouse":      {PerSecond: 2, Burst: 4},
+	"lever":           {PerSecond: 2, Burst: 4},
+	"rippling":        {PerSecond: 2, Burst: 4},
+	"smartrecruiters": {PerSecond: 2, Burst: 4},
+	"workable":        {PerSecond: 1, Burst: 2},
+}
+
+// RateLimit is a token bucket applied to each host separately.
+type RateLimit struct {
+	// PerSecond is the sustained number of requests allowed per second. Zero or less disables the limit.
+	PerSecond float64 `json:"per_second"`
+	// Burst is the number of requests that may be made at once before PerSecond applies.
+	Burst int `json:"burst"`
+}
+
+// WithRateLimit sets the rate limit for every ATS without a limit of its own.
+func WithRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) {
+		c.rateLimit = limit
+	}
+}
+
+// WithATSRateLimit sets the rate limit for hosts first requested on behalf of the named ATS.
+func WithATSRateLimit(ats string, limit RateLimit) ClientOption {
+	return func(c *Client) {
+		c.atsRateLimits[ats] = limit
+	}
+}
+
+// RateLimitFor returns the rate limit applied to requests made on behalf of the named ATS.
+func (c *Client) RateLimitFor(ats string) RateLimit {
+	limit, ok := c.atsRateLimits[ats]
+	if !ok {
+		return c.rateLimit
+	}
+
+	return limit
+}
+
+// wait blocks until the host of rawURL may be requested again. A host's limiter is created
+// with the limit of the ATS that requests it first.
+func (c *Client) wait(ctx context.Context, ats, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		// let the request itself report the bad URL
+		return nil //nolint:nilerr // the request fails with a better error
+	}
+
+	c.mu.Lock()
+
+	limiter, ok := c.limiters[parsed.Host]
+	if !ok {
+		limiter = c.RateLimitFor(ats).limiter()
+		c.limiters[parsed.Host] = limiter
+	}
+
+	c.mu.Unlock()
+
+	if limiter.Tokens() < 1 {
+		slog.DebugContext(ctx, "Waiting for rate limit", slog.String("host", parsed.Host))
+	}
+
+	err = limiter.Wait(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for rate limit on %s: %w", parsed.Host, err)
+	}
+
+	return nil
+}
+
+func (l RateLimit) limiter() *rate.Limiter {
+	if l.PerSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	return rate.NewLimiter(rate.Limit(l.PerSecond), max(l.Burst, 1))
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(nil,
+		WithRateLimit(RateLimit{PerSecond: 20, Burst: 1}),
+		WithATSRateLimit("lever", RateLimit{}),
+	)
+
+	// an unlimited ATS is not slowed down; it also claims the host's limiter first
+	start := time.Now()
+
+	for range 5 {
+		_, err := c.Do(WithATS(t.Context(), "lever"), http.MethodGet, server.URL, nil, nil)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Do() without a rate limit took %v, want under 100ms", elapsed)
+	}
+
+	// a second host gets its own limiter with the default limit
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer other.Close()
+
+	start = time.Now()
+
+	for range 5 {
+		_, err := c.Do(t.Context(), http.MethodGet, other.URL, nil, nil)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	}
+
+	// one request from the burst, then four more at 20 per second
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Do() with 20 requests per second took %v, want at least 150ms", elapsed)
+	}
+}
+
+func TestClient_RateLimitFor(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient(nil, WithATSRateLimit("greenhouse", RateLimit{PerSecond: 1, Burst: 1}))
+
+	if got := c.RateLimitFor("greenhouse"); got != (RateLimit{PerSecond: 1, Burst: 1}) {
+		t.Errorf("RateLimitFor(greenhouse) = %v, want override", got)
+	}
+
+	if got := c.RateLimitFor("workable"); got != DefaultATSRateLimits["workable"] {
+		t.Errorf("RateLimitFor(workable) = %v, want %v", got, DefaultATSRateLimits["workable"])
+	}
+
+	if got := c.RateLimitFor("unknown"); got != DefaultRateLimit {
+		t.Errorf("RateLimitFor(unknown) = %v, want %v", got, DefaultRateLimit)
+	}
+
+	if DefaultATSRateLimits["greenhouse"] == (RateLimit{PerSecond: 1, Burst: 1}) {
+		t.Errorf("WithATSRateLimit() modified DefaultATSRateLimits")
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/db"
+)
+
+var _ companyinfo.Store = (*Store)(nil)
+
+// GetCompanyInfo returns the stored company information for a source and company along
+// with when it was fetched. It returns a nil company if none is stored.
+func (s *Store) GetCompanyInfo(ctx context.Context, source, company string) (*models.Company, time.Time, error) {
+	row, err := s.queries.GetCompanyInfo(ctx, db.GetCompanyInfoParams{
+		Source:  source,
+		Company: company,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, time.Time{}, nil
+		}
+
+		return nil, time.Time{}, fmt.Errorf("error getting company info for %s/%s: %w", source, company, err)
+	}
+
+	data, err := jsonBytes(row.Data)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("error reading company info for %s/%s: %w", source, company, err)
+	}
+
+	info := models.NewCompany()
+
+	err = json.Unmarshal(data, info)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("error decoding company info for %s/%s: %w", source, company, err)
+	}
+
+	return info, row.FetchedAt.UTC(), nil
+}
+
+// SaveCompanyInfo stores the company information for a source and company, replacing any
+// previously stored version.
+func (s *Store) SaveCompanyInfo(ctx context.Context, source, company string, info *models.Company, fetchedAt time.Time) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("error encoding company info for %s/%s: %w", source, company, err)
+	}
+
+	err = s.queries.UpsertCompanyInfo(ctx, db.UpsertCompanyInfoParams{
+		Source:    source,
+		Company:   company,
+		Data:      string(data),
+		FetchedAt: fetchedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("error saving company info for %s/%s: %w", source, company, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+)
+
+func TestStore_CompanyInfo(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+
+	info, _, err := store.GetCompanyInfo(t.Context(), "bamboo", "acme")
+	if err != nil || info != nil {
+		t.Fatalf("GetCompanyInfo() = %v, %v, want nil, nil", info, err)
+	}
+
+	company := models.NewCompany()
+	company.Name = "Acme"
+	company.Logo = url.URL{Scheme: "https", Host: "acme.example", Path: "/logo.png"}
+
+	fetchedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	err = store.SaveCompanyInfo(t.Context(), "bamboo", "acme", company, fetchedAt)
+	if err != nil {
+		t.Fatalf("SaveCompanyInfo() error = %v", err)
+	}
+
+	company.Name = "Acme Inc"
+
+	err = store.SaveCompanyInfo(t.Context(), "bamboo", "acme", company, fetchedAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SaveCompanyInfo() error = %v", err)
+	}
+
+	info, got, err := store.GetCompanyInfo(t.Context(), "bamboo", "acme")
+	if err != nil {
+		t.Fatalf("GetCompanyInfo() error = %v", err)
+	}
+
+	if info.Name != "Acme Inc" || info.Logo.String() != "https://acme.example/logo.png" {
+		t.Errorf("GetCompanyInfo() = %+v, want Acme Inc with logo", info)
+	}
+
+	if !got.Equal(fetchedAt.Add(time.Hour)) {
+		t.Errorf("GetCompanyInfo() fetchedAt = %v, want %v", got, fetchedAt.Add(time.Hour))
+	}
+}
@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/db"
+)
+
+// StoredJob is a job together with its lifecycle as tracked across scrapes.
+type StoredJob struct {
+	ID          int64       `json:"id"`
+	Company     string      `json:"company"`
+	FirstSeenAt time.Time   `json:"first_seen_at"`
+	LastSeenAt  time.Time   `json:"last_seen_at"`
+	ClosedAt    *time.Time  `json:"closed_at,omitempty"`
+	Job         *models.Job `json:"job"`
+
+	// Changes holds the fields that changed in this scrape, if any.
+	Changes []models.FieldChange `json:"changes,omitempty"`
+}
+
+// IsOpen reports whether the job was present in the most recent scrape of its company.
+func (j *StoredJob) IsOpen() bool {
+	return j.ClosedAt == nil
+}
+
+// OpenFor returns how long the job has been (or was) open, based on when it was first and last seen.
+func (j *StoredJob) OpenFor() time.Duration {
+	if j.ClosedAt != nil {
+		return j.ClosedAt.Sub(j.FirstSeenAt)
+	}
+
+	return j.LastSeenAt.Sub(j.FirstSeenAt)
+}
+
+// SyncResult describes how a company scrape changed the stored jobs.
+type SyncResult struct {
+	// New holds jobs that had never been seen before.
+	New []*StoredJob `json:"new"`
+	// Seen holds jobs that were already stored (including reopened ones).
+	Seen []*StoredJob `json:"seen"`
+	// Updated holds the subset of Seen whose posting materially changed.
+	Updated []*StoredJob `json:"updated"`
+	// Closed holds jobs that were open but are missing from this scrape.
+	Closed []*StoredJob `json:"closed"`
+}
+
+// SyncOption configures SyncCompany.
+type SyncOption func(*syncOptions)
+
+type syncOptions struct {
+	skipClosing bool
+}
+
+// SkipClosing keeps open jobs that are missing from the run open. Use it when the scrape
+// was partial, since a job that failed to scrape would otherwise be closed.
+func SkipClosing() SyncOption {
+	return func(o *syncOptions) {
+		o.skipClosing = true
+	}
+}
+
+// SyncCompany records the result of a full ScrapeCompany run: every job is upserted and
+// marked as seen at seenAt, and open jobs for the same source and company that were not
+// part of the run are marked closed.
+func (s *Store) SyncCompany(ctx context.Context, source, company string, jobs []*models.Job, seenAt time.Time, opts ...SyncOption) (*SyncResult, error) {
+	seenAt = seenAt.UTC()
+	result := &SyncResult{}
+	options := newSyncOptions(opts)
+
+	err := s.inTx(ctx, func(queries *db.Queries) error {
+		for _, job := range jobs {
+			err := syncJob(ctx, queries, company, job, seenAt, result)
+			if err != nil {
+				return err
+			}
+		}
+
+		if options.skipClosing {
+			return nil
+		}
+
+		return closeMissingJobs(ctx, queries, source, company, seenAt, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logSync(ctx, source, company, result)
+
+	return result, nil
+}
+
+// SyncCompanySeq is the streaming form of SyncCompany for the loaders' StreamCompany
+// functions. Each job is saved as soon as it is yielded, in its own transaction, since the
+// stream may use the database itself while it runs. A *models.JobError for a dropped job
+// makes the sync partial, as with SkipClosing. Any other error stops the sync before
+// anything is closed and is returned along with what was saved so far.
+func (s *Store) SyncCompanySeq(ctx context.Context, source, company string, jobs iter.Seq2[*models.Job, error], seenAt time.Time, opts ...SyncOption) (*SyncResult, error) {
+	seenAt = seenAt.UTC()
+	result := &SyncResult{}
+	options := newSyncOptions(opts)
+
+	for job, err := range jobs {
+		if err != nil {
+			var jobErr *models.JobError
+			if !errors.As(err, &jobErr) {
+				return result, err //nolint:wrapcheck // stream errors are already wrapped
+			}
+
+			if jobErr.Dropped() {
+				options.skipClosing = true
+			}
+
+			continue
+		}
+
+		err = s.inTx(ctx, func(queries *db.Queries) error {
+			return syncJob(ctx, queries, company, job, seenAt, result)
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if !options.skipClosing {
+		err := s.inTx(ctx, func(queries *db.Queries) error {
+			return closeMissingJobs(ctx, queries, source, company, seenAt, result)
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	logSync(ctx, source, company, result)
+
+	return result, nil
+}
+
+func newSyncOptions(opts []SyncOption) syncOptions {
+	options := syncOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// syncJob saves a single job and files it under New, Updated or Seen.
+func syncJob(ctx context.Context, queries *db.Queries, company string, job *models.Job, seenAt time.Time, result *SyncResult) error {
+	saved, err := saveJob(ctx, queries, company, job, seenAt)
+	if err != nil {
+		return err
+	}
+
+	stored, err := toStoredJob(saved.row)
+	if err != nil {
+		return err
+	}
+
+	stored.Changes = saved.changes
+
+	switch {
+	case stored.FirstSeenAt.Equal(seenAt):
+		result.New = append(result.New, stored)
+	case len(stored.Changes) > 0:
+		result.Updated = append(result.Updated, stored)
+		result.Seen = append(result.Seen, stored)
+	default:
+		result.Seen = append(result.Seen, stored)
+	}
+
+	return nil
+}
+
+// closeMissingJobs closes the company's open jobs that were not seen at seenAt.
+func closeMissingJobs(ctx context.Context, queries *db.Queries, source, company string, seenAt time.Time, result *SyncResult) error {
+	rows, err := queries.CloseMissingJobs(ctx, db.CloseMissingJobsParams{
+		ClosedAt: sql.NullTime{Time: seenAt, Valid: true},
+		Source:   nullString(source),
+		Company:  nullString(company),
+	})
+	if err != nil {
+		return fmt.Errorf("error closing missing jobs for %s/%s: %w", source, company, err)
+	}
+
+	for _, row := range rows {
+		stored, err := toStoredJob(row)
+		if err != nil {
+			return err
+		}
+
+		result.Closed = append(result.Closed, stored)
+	}
+
+	return nil
+}
+
+func logSync(ctx context.Context, source, company string, result *SyncResult) {
+	slog.InfoContext(ctx, "Synced company jobs",
+		slog.String("source", source),
+		slog.String("company", company),
+		slog.Int("new", len(result.New)),
+		slog.Int("seen", len(result.Seen)),
+		slog.Int("updated", len(result.Updated)),
+		slog.Int("closed", len(result.Closed)),
+	)
+}
+
+// CloseJob marks a single open job as closed at closedAt, e.g. after the ATS reported
+// models.ErrJobGone for it. It reports whether an open job was found.
+func (s *Store) CloseJob(ctx context.Context, source, sourceID string, closedAt time.Time) (bool, error) {
+	rows, err := s.queries.CloseJob(ctx, db.CloseJobParams{
+		ClosedAt: sql.NullTime{Time: closedAt.UTC(), Valid: true},
+		Source:   nullString(source),
+		SourceID: nullString(sourceID),
+	})
+	if err != nil {
+		return false, fmt.Errorf("error closing job %s/%s: %w", source, sourceID, err)
+	}
+
+	return rows > 0, nil
+}
+
+// ListCompanyJobs returns every stored job, open or closed, for a source and company.
+func (s *Store) ListCompanyJobs(ctx context.Context, source, company string) ([]*StoredJob, error) {
+	rows, err := s.queries.ListCompanyJobs(ctx, db.ListCompanyJobsParams{
+		Source:  nullString(source),
+		Company: nullString(company),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing jobs for %s/%s: %w", source, company, err)
+	}
+
+	jobs := make([]*StoredJob, 0, len(rows))
+
+	for _, row := range rows {
+		stored, err := toStoredJob(row)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, stored)
+	}
+
+	return jobs, nil
+}
+
+func toStoredJob(row db.Job) (*StoredJob, error) {
+	job, err := decodeJob(row)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &StoredJob{
+		ID:          row.ID,
+		Company:     row.Company.String,
+		FirstSeenAt: row.FirstSeenAt.Time.UTC(),
+		LastSeenAt:  row.LastSeenAt.Time.UTC(),
+		Job:         job,
+	}
+
+	if row.ClosedAt.Valid {
+		closedAt := row.ClosedAt.Time.UTC()
+		stored.ClosedAt = &closedAt
+	}
+
+	return stored, nil
+}
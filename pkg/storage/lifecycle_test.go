@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+)
+
+func lifecycleJob(id string) *models.Job {
+	job := models.NewJob("lever", nil)
+	job.SourceID = id
+	job.URL = "https://jobs.lever.co/acme/" + id
+	job.Title = "Job " + id
+
+	return job
+}
+
+func TestStore_SyncCompany(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	first := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	third := second.Add(24 * time.Hour)
+
+	result, err := store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{lifecycleJob("a"), lifecycleJob("b")}, first)
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	if len(result.New) != 2 || len(result.Seen) != 0 || len(result.Closed) != 0 {
+		t.Fatalf("SyncCompany() first run new/seen/closed = %d/%d/%d, want 2/0/0", len(result.New), len(result.Seen), len(result.Closed))
+	}
+
+	// "b" was pulled and "c" was posted.
+	result, err = store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{lifecycleJob("a"), lifecycleJob("c")}, second)
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	if len(result.New) != 1 || len(result.Seen) != 1 || len(result.Closed) != 1 {
+		t.Fatalf("SyncCompany() second run new/seen/closed = %d/%d/%d, want 1/1/1", len(result.New), len(result.Seen), len(result.Closed))
+	}
+
+	closed := result.Closed[0]
+	if closed.Job.SourceID != "b" {
+		t.Errorf("SyncCompany() closed job = %v, want b", closed.Job.SourceID)
+	}
+
+	if closed.IsOpen() || !closed.ClosedAt.Equal(second) {
+		t.Errorf("SyncCompany() ClosedAt = %v, want %v", closed.ClosedAt, second)
+	}
+
+	if closed.OpenFor() != 24*time.Hour {
+		t.Errorf("SyncCompany() OpenFor = %v, want 24h", closed.OpenFor())
+	}
+
+	// "b" comes back.
+	result, err = store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{lifecycleJob("a"), lifecycleJob("b"), lifecycleJob("c")}, third)
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	if len(result.New) != 0 || len(result.Seen) != 3 || len(result.Closed) != 0 {
+		t.Fatalf("SyncCompany() third run new/seen/closed = %d/%d/%d, want 0/3/0", len(result.New), len(result.Seen), len(result.Closed))
+	}
+
+	jobs, err := store.ListCompanyJobs(t.Context(), "lever", "acme")
+	if err != nil {
+		t.Fatalf("ListCompanyJobs() error = %v", err)
+	}
+
+	for _, job := range jobs {
+		if !job.IsOpen() {
+			t.Errorf("ListCompanyJobs() job %v is closed, want open", job.Job.SourceID)
+		}
+
+		if !job.LastSeenAt.Equal(third) {
+			t.Errorf("ListCompanyJobs() job %v LastSeenAt = %v, want %v", job.Job.SourceID, job.LastSeenAt, third)
+		}
+
+		if job.Job.SourceID == "a" && !job.FirstSeenAt.Equal(first) {
+			t.Errorf("ListCompanyJobs() job a FirstSeenAt = %v, want %v", job.FirstSeenAt, first)
+		}
+	}
+
+	// Other companies are untouched.
+	result, err = store.SyncCompany(t.Context(), "lever", "other", nil, third.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	if len(result.Closed) != 0 {
+		t.Errorf("SyncCompany() closed %d jobs of another company", len(result.Closed))
+	}
+}
+
+func TestStore_SyncCompanySkipClosing(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	first := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{lifecycleJob("a"), lifecycleJob("b")}, first)
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	// "b" failed to scrape, so it must stay open
+	result, err := store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{lifecycleJob("a")}, first.Add(time.Hour), SkipClosing())
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	if len(result.Seen) != 1 || len(result.Closed) != 0 {
+		t.Errorf("SyncCompany() seen/closed = %d/%d, want 1/0", len(result.Seen), len(result.Closed))
+	}
+}
+
+func TestStore_SyncCompanySeq(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	first := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{lifecycleJob("a"), lifecycleJob("b"), lifecycleJob("c")}, first)
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	stream := func(errs ...error) iter.Seq2[*models.Job, error] {
+		return func(yield func(*models.Job, error) bool) {
+			if !yield(lifecycleJob("a"), nil) {
+				return
+			}
+
+			for _, err := range errs {
+				if !yield(nil, err) {
+					return
+				}
+			}
+		}
+	}
+
+	// "b" failed to scrape, so nothing is closed
+	result, err := store.SyncCompanySeq(t.Context(), "lever", "acme", stream(&models.JobError{JobID: "b", Stage: models.StageDetail, Err: models.ErrJobGone}), first.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SyncCompanySeq() error = %v", err)
+	}
+
+	if len(result.Seen) != 1 || len(result.Closed) != 0 {
+		t.Errorf("SyncCompanySeq() partial seen/closed = %d/%d, want 1/0", len(result.Seen), len(result.Closed))
+	}
+
+	// a failed scrape keeps what was saved but closes nothing
+	errBoard := errors.New("board is down")
+
+	result, err = store.SyncCompanySeq(t.Context(), "lever", "acme", stream(errBoard), first.Add(2*time.Hour))
+	if !errors.Is(err, errBoard) {
+		t.Errorf("SyncCompanySeq() error = %v, want %v", err, errBoard)
+	}
+
+	if len(result.Seen) != 1 || len(result.Closed) != 0 {
+		t.Errorf("SyncCompanySeq() failed seen/closed = %d/%d, want 1/0", len(result.Seen), len(result.Closed))
+	}
+
+	// a complete run closes "b" and "c"
+	result, err = store.SyncCompanySeq(t.Context(), "lever", "acme", stream(), first.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("SyncCompanySeq() error = %v", err)
+	}
+
+	if len(result.Seen) != 1 || len(result.Closed) != 2 {
+		t.Errorf("SyncCompanySeq() complete seen/closed = %d/%d, want 1/2", len(result.Seen), len(result.Closed))
+	}
+}
+
+func TestStore_CloseJob(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	seenAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{lifecycleJob("a")}, seenAt)
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	for _, want := range []bool{true, false} {
+		closed, err := store.CloseJob(t.Context(), "lever", "a", seenAt.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("CloseJob() error = %v", err)
+		}
+
+		if closed != want {
+			t.Errorf("CloseJob() = %v, want %v", closed, want)
+		}
+	}
+
+	jobs, err := store.ListCompanyJobs(t.Context(), "lever", "acme")
+	if err != nil {
+		t.Fatalf("ListCompanyJobs() error = %v", err)
+	}
+
+	if jobs[0].IsOpen() || !jobs[0].ClosedAt.Equal(seenAt.Add(time.Hour)) {
+		t.Errorf("ListCompanyJobs()[0].ClosedAt = %v, want %v", jobs[0].ClosedAt, seenAt.Add(time.Hour))
+	}
+}
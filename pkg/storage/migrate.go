@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/amalgamated-tools/jobscraping/db/migrations"
+)
+
+const (
+	migrateUpMarker   = "-- migrate:up"
+	migrateDownMarker = "-- migrate:down"
+)
+
+var (
+	// ErrInvalidMigration is returned when a migration file cannot be parsed.
+	ErrInvalidMigration = errors.New("invalid migration")
+	// ErrNoMigrationsApplied is returned when rolling back a database without applied migrations.
+	ErrNoMigrationsApplied = errors.New("no migrations applied")
+)
+
+// Migration is a single dbmate-style migration.
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied.
+type MigrationStatus struct {
+	Version string `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+// LoadMigrations parses every <version>_<name>.sql file in fsys, sorted by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("error listing migrations: %w", err)
+	}
+
+	result := make([]Migration, 0, len(files))
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %s: %w", file, err)
+		}
+
+		migration, err := parseMigration(file, string(data))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, migration)
+	}
+
+	slices.SortFunc(result, func(a, b Migration) int {
+		return strings.Compare(a.Version, b.Version)
+	})
+
+	return result, nil
+}
+
+func parseMigration(file, contents string) (Migration, error) {
+	base := strings.TrimSuffix(path.Base(file), ".sql")
+
+	version, name, ok := strings.Cut(base, "_")
+	if !ok || version == "" {
+		return Migration{}, fmt.Errorf("%w: %s: file name must be <version>_<name>.sql", ErrInvalidMigration, file)
+	}
+
+	upIdx := strings.Index(contents, migrateUpMarker)
+	if upIdx < 0 {
+		return Migration{}, fmt.Errorf("%w: %s: missing %q", ErrInvalidMigration, file, migrateUpMarker)
+	}
+
+	up := contents[upIdx+len(migrateUpMarker):]
+	down := ""
+
+	downIdx := strings.Index(up, migrateDownMarker)
+	if downIdx >= 0 {
+		down = up[downIdx+len(migrateDownMarker):]
+		up = up[:downIdx]
+	}
+
+	return Migration{
+		Version: version,
+		Name:    name,
+		Up:      stripMarkerOptions(up),
+		Down:    stripMarkerOptions(down),
+	}, nil
+}
+
+// stripMarkerOptions drops anything left on the marker line, such as dbmate's "transaction:false".
+func stripMarkerOptions(section string) string {
+	_, rest, found := strings.Cut(section, "\n")
+	if !found {
+		return strings.TrimSpace(section)
+	}
+
+	return strings.TrimSpace(rest)
+}
+
+// MigrateUp applies every pending embedded migration and returns the versions applied.
+func (s *Store) MigrateUp(ctx context.Context) ([]string, error) {
+	all, applied, err := s.loadMigrationState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0)
+
+	for _, migration := range all {
+		if applied[migration.Version] {
+			continue
+		}
+
+		err = s.runMigration(ctx, migration.Up, "INSERT INTO schema_migrations (version) VALUES (?)", migration.Version)
+		if err != nil {
+			return versions, fmt.Errorf("error applying migration %s_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		slog.InfoContext(ctx, "Applied migration", slog.String("version", migration.Version), slog.String("name", migration.Name))
+		versions = append(versions, migration.Version)
+	}
+
+	return versions, nil
+}
+
+// MigrateDown rolls back the most recently applied migration and returns its version.
+func (s *Store) MigrateDown(ctx context.Context) (string, error) {
+	all, applied, err := s.loadMigrationState(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, migration := range slices.Backward(all) {
+		if !applied[migration.Version] {
+			continue
+		}
+
+		err = s.runMigration(ctx, migration.Down, "DELETE FROM schema_migrations WHERE version = ?", migration.Version)
+		if err != nil {
+			return "", fmt.Errorf("error rolling back migration %s_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		slog.InfoContext(ctx, "Rolled back migration", slog.String("version", migration.Version), slog.String("name", migration.Name))
+
+		return migration.Version, nil
+	}
+
+	return "", ErrNoMigrationsApplied
+}
+
+// MigrationStatus lists every embedded migration and whether it has been applied.
+func (s *Store) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	all, applied, err := s.loadMigrationState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(all))
+	for _, migration := range all {
+		statuses = append(statuses, MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: applied[migration.Version],
+		})
+	}
+
+	return statuses, nil
+}
+
+func (s *Store) loadMigrationState(ctx context.Context) ([]Migration, map[string]bool, error) {
+	all, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = s.conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS "schema_migrations" (version varchar(128) primary key)`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating schema_migrations table: %w", err)
+	}
+
+	rows, err := s.conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading schema_migrations: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	applied := make(map[string]bool)
+
+	for rows.Next() {
+		var version string
+
+		err = rows.Scan(&version)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading schema_migrations: %w", err)
+		}
+
+		applied[version] = true
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading schema_migrations: %w", err)
+	}
+
+	return all, applied, nil
+}
+
+// runMigration executes a migration body and records it in schema_migrations in one transaction.
+func (s *Store) runMigration(ctx context.Context, body, record, version string) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if body != "" {
+		_, err = tx.ExecContext(ctx, body)
+		if err != nil {
+			return fmt.Errorf("error executing migration: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, record, version)
+	if err != nil {
+		return fmt.Errorf("error updating schema_migrations: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
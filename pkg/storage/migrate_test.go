@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"20240102000000_second.sql": {Data: []byte("-- migrate:up transaction:false\nCREATE TABLE b (id INTEGER);\n\n-- migrate:down\nDROP TABLE b;\n")},
+		"20240101000000_first.sql":  {Data: []byte("-- migrate:up\nCREATE TABLE a (id INTEGER);\n")},
+	}
+
+	got, err := LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("LoadMigrations() len = %v, want 2", len(got))
+	}
+
+	if got[0].Version != "20240101000000" || got[0].Name != "first" {
+		t.Errorf("LoadMigrations()[0] = %v_%v, want 20240101000000_first", got[0].Version, got[0].Name)
+	}
+
+	if got[0].Down != "" {
+		t.Errorf("LoadMigrations()[0].Down = %q, want empty", got[0].Down)
+	}
+
+	if got[1].Up != "CREATE TABLE b (id INTEGER);" {
+		t.Errorf("LoadMigrations()[1].Up = %q", got[1].Up)
+	}
+
+	if got[1].Down != "DROP TABLE b;" {
+		t.Errorf("LoadMigrations()[1].Down = %q", got[1].Down)
+	}
+
+	_, err = LoadMigrations(fstest.MapFS{"bad.sql": {Data: []byte("CREATE TABLE c (id INTEGER);")}})
+	if !errors.Is(err, ErrInvalidMigration) {
+		t.Errorf("LoadMigrations() error = %v, want %v", err, ErrInvalidMigration)
+	}
+}
+
+func TestStore_MigrateUpDown(t *testing.T) {
+	t.Parallel()
+
+	store, err := Open(t.Context(), ":memory:")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	defer func() { _ = store.Close() }()
+
+	applied, err := store.MigrateUp(t.Context())
+	if err != nil {
+		t.Fatalf("MigrateUp() error = %v", err)
+	}
+
+	if len(applied) == 0 {
+		t.Fatal("MigrateUp() applied no migrations")
+	}
+
+	again, err := store.MigrateUp(t.Context())
+	if err != nil {
+		t.Fatalf("MigrateUp() second call error = %v", err)
+	}
+
+	if len(again) != 0 {
+		t.Errorf("MigrateUp() second call applied %v, want none", again)
+	}
+
+	for range applied {
+		_, err = store.MigrateDown(t.Context())
+		if err != nil {
+			t.Fatalf("MigrateDown() error = %v", err)
+		}
+	}
+
+	_, err = store.MigrateDown(t.Context())
+	if !errors.Is(err, ErrNoMigrationsApplied) {
+		t.Errorf("MigrateDown() error = %v, want %v", err, ErrNoMigrationsApplied)
+	}
+
+	statuses, err := store.MigrationStatus(t.Context())
+	if err != nil {
+		t.Fatalf("MigrationStatus() error = %v", err)
+	}
+
+	for _, status := range statuses {
+		if status.Applied {
+			t.Errorf("MigrationStatus() %v applied after rolling everything back", status.Version)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/db"
+)
+
+// Revision is a materially distinct version of a job posting.
+type Revision struct {
+	ID        int64                `json:"id"`
+	CreatedAt time.Time            `json:"created_at"`
+	Job       *models.Job          `json:"job"`
+	Changes   []models.FieldChange `json:"changes,omitempty"`
+}
+
+// JobHistory returns every recorded revision of a job, oldest first. The first revision
+// has no changes; each later one lists the fields that differ from the revision before it.
+func (s *Store) JobHistory(ctx context.Context, source, sourceID string) ([]*Revision, error) {
+	row, err := s.queries.GetJobBySource(ctx, db.GetJobBySourceParams{
+		Source:   nullString(source),
+		SourceID: nullString(sourceID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s/%s", ErrJobNotFound, source, sourceID)
+		}
+
+		return nil, fmt.Errorf("error getting job %s/%s: %w", source, sourceID, err)
+	}
+
+	rows, err := s.queries.ListJobRevisions(ctx, row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing revisions for job %s/%s: %w", source, sourceID, err)
+	}
+
+	revisions := make([]*Revision, 0, len(rows))
+
+	for _, rev := range rows {
+		job, err := decodeJobData(rev.JobID, rev.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		revision := &Revision{
+			ID:        rev.ID,
+			CreatedAt: rev.CreatedAt.UTC(),
+			Job:       job,
+		}
+
+		if rev.Changes != nil {
+			data, err := jsonBytes(rev.Changes)
+			if err != nil {
+				return nil, fmt.Errorf("error reading changes for revision %d: %w", rev.ID, err)
+			}
+
+			err = json.Unmarshal(data, &revision.Changes)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding changes for revision %d: %w", rev.ID, err)
+			}
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+func recordRevision(ctx context.Context, queries *db.Queries, jobID int64, data []byte, changes []models.FieldChange, createdAt time.Time) error {
+	var encodedChanges any
+
+	if len(changes) > 0 {
+		encoded, err := json.Marshal(changes)
+		if err != nil {
+			return fmt.Errorf("error encoding changes for job %d: %w", jobID, err)
+		}
+
+		encodedChanges = string(encoded)
+	}
+
+	_, err := queries.CreateJobRevision(ctx, db.CreateJobRevisionParams{
+		JobID:     jobID,
+		Data:      string(data),
+		Changes:   encodedChanges,
+		CreatedAt: createdAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error recording revision for job %d: %w", jobID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+)
+
+func TestStore_JobHistory(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+	seenAt := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	original := lifecycleJob("x")
+	original.EmploymentType = models.FullTime
+	original.LocationType = models.RemoteLocation
+	original.MinCompensation = 100000
+
+	_, err := store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{original}, seenAt)
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	// An identical scrape must not record a new revision.
+	result, err := store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{cloneJob(original)}, seenAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	if len(result.Updated) != 0 {
+		t.Fatalf("SyncCompany() unchanged job Updated = %v, want none", result.Updated[0].Changes)
+	}
+
+	changed := cloneJob(original)
+	changed.MinCompensation = 120000
+	changed.LocationType = models.HybridLocation
+
+	result, err = store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{changed}, seenAt.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	if len(result.Updated) != 1 {
+		t.Fatalf("SyncCompany() changed job Updated len = %v, want 1", len(result.Updated))
+	}
+
+	history, err := store.JobHistory(t.Context(), "lever", "x")
+	if err != nil {
+		t.Fatalf("JobHistory() error = %v", err)
+	}
+
+	if len(history) != 2 {
+		t.Fatalf("JobHistory() len = %v, want 2", len(history))
+	}
+
+	if len(history[0].Changes) != 0 {
+		t.Errorf("JobHistory()[0].Changes = %v, want none", history[0].Changes)
+	}
+
+	if len(history[1].Changes) != 2 {
+		t.Fatalf("JobHistory()[1].Changes = %v, want 2 changes", history[1].Changes)
+	}
+
+	if history[1].Changes[0].Field != "location_type" || history[1].Changes[0].New != "Hybrid" {
+		t.Errorf("JobHistory()[1].Changes[0] = %+v, want location_type -> Hybrid", history[1].Changes[0])
+	}
+
+	if history[1].Job.MinCompensation != 120000 {
+		t.Errorf("JobHistory()[1].Job.MinCompensation = %v, want 120000", history[1].Job.MinCompensation)
+	}
+
+	_, err = store.JobHistory(t.Context(), "lever", "missing")
+	if !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("JobHistory() error = %v, want %v", err, ErrJobNotFound)
+	}
+}
+
+func cloneJob(job *models.Job) *models.Job {
+	clone := *job
+
+	return &clone
+}
@@ -0,0 +1,292 @@
+// Package storage persists scraped jobs into the SQLite jobs table.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/db"
+
+	_ "modernc.org/sqlite" // register the sqlite driver
+)
+
+var (
+	// ErrJobNotFound is returned when a job does not exist in the database.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrInvalidJob is returned when a job is missing the fields required to store it.
+	ErrInvalidJob = errors.New("invalid job")
+	// ErrInvalidDatabaseURL is returned when a database URL cannot be turned into a SQLite path.
+	ErrInvalidDatabaseURL = errors.New("invalid database URL")
+)
+
+// Store reads and writes models.Job values in the jobs table.
+type Store struct {
+	conn    *sql.DB
+	queries *db.Queries
+}
+
+// Open opens the SQLite database at the given path. Both plain paths and
+// dbmate-style URLs such as "sqlite:db/jobscraping.db" are accepted.
+func Open(ctx context.Context, databaseURL string) (*Store, error) {
+	path := ParseDatabaseURL(databaseURL)
+	if path == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDatabaseURL, databaseURL)
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	conn, err := sql.Open("sqlite", path+separator+"_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, fmt.Errorf("error opening database %s: %w", path, err)
+	}
+
+	// SQLite only allows a single writer, and a single connection keeps
+	// in-memory databases alive for the lifetime of the store.
+	conn.SetMaxOpenConns(1)
+
+	err = conn.PingContext(ctx)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("error connecting to database %s: %w", path, err)
+	}
+
+	slog.DebugContext(ctx, "Opened database", slog.String("path", path))
+
+	return New(conn), nil
+}
+
+// ParseDatabaseURL turns a dbmate-style "sqlite:" URL into a path the sqlite driver understands.
+func ParseDatabaseURL(databaseURL string) string {
+	path := strings.TrimSpace(databaseURL)
+	path = strings.TrimPrefix(path, "sqlite3:")
+	path = strings.TrimPrefix(path, "sqlite:")
+	path = strings.TrimPrefix(path, "//")
+
+	return path
+}
+
+// New wraps an existing database connection.
+func New(conn *sql.DB) *Store {
+	return &Store{
+		conn:    conn,
+		queries: db.New(conn),
+	}
+}
+
+// Close closes the underlying database connection.
+func (s *Store) Close() error {
+	return s.conn.Close() //nolint:wrapcheck // we want to return the original error
+}
+
+// DB returns the underlying database connection.
+func (s *Store) DB() *sql.DB {
+	return s.conn
+}
+
+// SaveJob inserts a job, or updates the existing row with the same source and source ID,
+// and marks it as seen now. It returns the row ID.
+func (s *Store) SaveJob(ctx context.Context, company string, job *models.Job) (int64, error) {
+	saved, err := saveJob(ctx, s.queries, company, job, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return saved.row.ID, nil
+}
+
+// SaveJobs upserts a batch of jobs for a company in a single transaction and marks them as seen now.
+func (s *Store) SaveJobs(ctx context.Context, company string, jobs []*models.Job) error {
+	seenAt := time.Now().UTC()
+
+	return s.inTx(ctx, func(queries *db.Queries) error {
+		for _, job := range jobs {
+			_, err := saveJob(ctx, queries, company, job, seenAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// inTx runs fn in a transaction, committing if it returns nil.
+func (s *Store) inTx(ctx context.Context, fn func(queries *db.Queries) error) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = fn(s.queries.WithTx(tx))
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob returns the stored job with the given source and source ID.
+func (s *Store) GetJob(ctx context.Context, source, sourceID string) (*models.Job, error) {
+	job, err := lookupJob(ctx, s.queries, source, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job == nil {
+		return nil, fmt.Errorf("%w: %s/%s", ErrJobNotFound, source, sourceID)
+	}
+
+	return job, nil
+}
+
+// ListJobs returns every stored job.
+func (s *Store) ListJobs(ctx context.Context) ([]*models.Job, error) {
+	rows, err := s.queries.ListJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing jobs: %w", err)
+	}
+
+	jobs := make([]*models.Job, 0, len(rows))
+
+	for _, row := range rows {
+		job, err := decodeJob(row)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// savedJob is the outcome of upserting a single job.
+type savedJob struct {
+	row     db.Job
+	changes []models.FieldChange
+}
+
+func saveJob(ctx context.Context, queries *db.Queries, company string, job *models.Job, seenAt time.Time) (*savedJob, error) {
+	if job == nil || job.Source == "" || job.SourceID == "" || job.URL == "" {
+		return nil, fmt.Errorf("%w: source, source ID and URL are required", ErrInvalidJob)
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding job %s/%s: %w", job.Source, job.SourceID, err)
+	}
+
+	previous, err := lookupJob(ctx, queries, job.Source, job.SourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := queries.UpsertJob(ctx, db.UpsertJobParams{
+		AbsoluteUrl: job.URL,
+		Data:        string(data),
+		Company:     nullString(company),
+		SeenAt:      sql.NullTime{Time: seenAt, Valid: true},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error saving job", slog.String("source", job.Source), slog.String("source_id", job.SourceID), slog.Any("error", err))
+		return nil, fmt.Errorf("error saving job %s/%s: %w", job.Source, job.SourceID, err)
+	}
+
+	slog.DebugContext(ctx, "Saved job", slog.Int64("id", row.ID), slog.String("source", job.Source), slog.String("source_id", job.SourceID))
+
+	saved := &savedJob{row: row}
+
+	// The first version of a job is always recorded; after that only material changes are.
+	if previous != nil {
+		saved.changes = models.Diff(previous, job)
+		if len(saved.changes) == 0 {
+			return saved, nil
+		}
+	}
+
+	err = recordRevision(ctx, queries, row.ID, data, saved.changes, seenAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// lookupJob returns the currently stored version of a job, or nil if it has never been stored.
+func lookupJob(ctx context.Context, queries *db.Queries, source, sourceID string) (*models.Job, error) {
+	row, err := queries.GetJobBySource(ctx, db.GetJobBySourceParams{
+		Source:   nullString(source),
+		SourceID: nullString(sourceID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil // a missing job is not an error here
+		}
+
+		return nil, fmt.Errorf("error getting job %s/%s: %w", source, sourceID, err)
+	}
+
+	return decodeJob(row)
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func decodeJob(row db.Job) (*models.Job, error) {
+	return decodeJobData(row.ID, row.Data)
+}
+
+func decodeJobData(id int64, value any) (*models.Job, error) {
+	data, err := jsonBytes(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: job %d: %w", ErrInvalidJob, id, err)
+	}
+
+	// Decode into a zero Job rather than models.NewJob: enum fields tagged omitempty are
+	// left out of the JSON when they hold their zero value (e.g. FullTime, RemoteLocation),
+	// so NewJob's "unknown" defaults would not be overwritten.
+	job := &models.Job{}
+
+	err = json.Unmarshal(data, job)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding job %d: %w", id, err)
+	}
+
+	if job.Company == nil {
+		job.Company = models.NewCompany()
+	}
+
+	return job, nil
+}
+
+// jsonBytes converts a JSON column value scanned by the sqlite driver into bytes.
+func jsonBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected JSON column type %T", ErrInvalidJob, value)
+	}
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(t.Context(), ":memory:")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = store.Close() })
+
+	_, err = store.MigrateUp(t.Context())
+	if err != nil {
+		t.Fatalf("MigrateUp() error = %v", err)
+	}
+
+	return store
+}
+
+func newJob(title string) *models.Job {
+	job := models.NewJob("greenhouse", nil)
+	job.SourceID = "123"
+	job.URL = "https://boards.greenhouse.io/acme/jobs/123"
+	job.Title = title
+
+	return job
+}
+
+func TestStore_SaveJobUpserts(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+
+	id, err := store.SaveJob(t.Context(), "acme", newJob("Engineer"))
+	if err != nil {
+		t.Fatalf("SaveJob() error = %v", err)
+	}
+
+	updated := newJob("Senior Engineer")
+	updated.URL = "https://job-boards.greenhouse.io/acme/jobs/123"
+
+	updatedID, err := store.SaveJob(t.Context(), "acme", updated)
+	if err != nil {
+		t.Fatalf("SaveJob() second call error = %v", err)
+	}
+
+	if updatedID != id {
+		t.Errorf("SaveJob() id = %v, want %v", updatedID, id)
+	}
+
+	job, err := store.GetJob(t.Context(), "greenhouse", "123")
+	if err != nil {
+		t.Fatalf("GetJob() error = %v", err)
+	}
+
+	if job.Title != "Senior Engineer" {
+		t.Errorf("GetJob() Title = %v, want %v", job.Title, "Senior Engineer")
+	}
+
+	if job.URL != updated.URL {
+		t.Errorf("GetJob() URL = %v, want %v", job.URL, updated.URL)
+	}
+
+	jobs, err := store.ListJobs(t.Context())
+	if err != nil {
+		t.Fatalf("ListJobs() error = %v", err)
+	}
+
+	if len(jobs) != 1 {
+		t.Errorf("ListJobs() len = %v, want 1", len(jobs))
+	}
+}
+
+func TestStore_SaveJobsAndErrors(t *testing.T) {
+	t.Parallel()
+
+	store := newTestStore(t)
+
+	second := newJob("Designer")
+	second.SourceID = "456"
+	second.URL = "https://boards.greenhouse.io/acme/jobs/456"
+
+	err := store.SaveJobs(t.Context(), "acme", []*models.Job{newJob("Engineer"), second})
+	if err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+
+	_, err = store.SaveJob(t.Context(), "acme", models.NewJob("greenhouse", nil))
+	if !errors.Is(err, ErrInvalidJob) {
+		t.Errorf("SaveJob() error = %v, want %v", err, ErrInvalidJob)
+	}
+
+	_, err = store.GetJob(t.Context(), "greenhouse", "789")
+	if !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("GetJob() error = %v, want %v", err, ErrJobNotFound)
+	}
+}
+
+func TestParseDatabaseURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"sqlite:db/jobscraping.db": "db/jobscraping.db",
+		"sqlite:///tmp/jobs.db":    "/tmp/jobs.db",
+		"jobs.db":                  "jobs.db",
+	}
+
+	for in, want := range tests {
+		if got := ParseDatabaseURL(in); got != want {
+			t.Errorf("ParseDatabaseURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+// Package watch scrapes the companies in a watchlist on their schedules, saves the results
+// to the database and reports how each company's jobs changed.
+package watch
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/companyinfo"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/storage"
+	"github.com/amalgamated-tools/jobscraping/pkg/watchlist"
+)
+
+// DefaultShutdownTimeout is how long scrapes in flight are given to finish once a Watcher
+// is stopped.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// ErrNoCompanies is returned by Run when the watchlist has no enabled companies.
+var ErrNoCompanies = errors.New("no companies to watch")
+
+// EventType is the kind of change an Event reports.
+type EventType string
+
+const (
+	// EventNew is reported for a job seen for the first time.
+	EventNew EventType = "new"
+	// EventUpdated is reported for a job whose posting materially changed.
+	EventUpdated EventType = "updated"
+	// EventClosed is reported for an open job that is no longer listed.
+	EventClosed EventType = "closed"
+)
+
+// Event is a change to a company's jobs found by a scrape.
+type Event struct {
+	Type    EventType          `json:"type"`
+	Source  string             `json:"source"`
+	Company string             `json:"company"`
+	Time    time.Time          `json:"time"`
+	Job     *storage.StoredJob `json:"job"`
+}
+
+// Handler is called with each event. Calls are serialized, so a Handler doesn't need to be
+// safe for concurrent use.
+type Handler func(ctx context.Context, event Event)
+
+// Option configures a Watcher.
+type Option func(*Watcher)
+
+// WithShutdownTimeout sets how long scrapes in flight are given to finish once the
+// Watcher is stopped, DefaultShutdownTimeout by default.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(w *Watcher) {
+		w.shutdownTimeout = timeout
+	}
+}
+
+// WithCompanyInfoCache sets the options of the company info cache each scrape is given,
+// such as a store to reuse company info from. By default the cache is in memory only.
+func WithCompanyInfoCache(opts ...companyinfo.Option) Option {
+	return func(w *Watcher) {
+		w.companyInfo = opts
+	}
+}
+
+// Watcher scrapes the companies in a watchlist on their schedules.
+type Watcher struct {
+	store           *storage.Store
+	list            *watchlist.Watchlist
+	handle          Handler
+	shutdownTimeout time.Duration
+	companyInfo     []companyinfo.Option
+
+	// mu serializes calls to handle.
+	mu sync.Mutex
+}
+
+// New returns a Watcher that saves the scrapes of the companies in list to store and calls
+// handle with the changes.
+func New(store *storage.Store, list *watchlist.Watchlist, handle Handler, opts ...Option) *Watcher {
+	w := &Watcher{
+		store:           store,
+		list:            list,
+		handle:          handle,
+		shutdownTimeout: DefaultShutdownTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Run scrapes every enabled company straight away and then on its schedule until ctx is
+// done. Scrapes of the same company never overlap: scheduled times that pass while the
+// previous scrape is still running are skipped. Once ctx is done no new scrapes start, and
+// the ones in flight are given the shutdown timeout to finish before they are cancelled.
+// Run returns when they have all stopped.
+func (w *Watcher) Run(ctx context.Context) error {
+	companies := w.list.Enabled()
+	if len(companies) == 0 {
+		return ErrNoCompanies
+	}
+
+	// scrapes outlive ctx by up to the shutdown timeout, so that stopping the watcher
+	// doesn't cut them off halfway and leave their companies partially synced
+	scrapeCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(w.shutdownTimeout, cancel)
+	})
+	defer stop()
+
+	var wg sync.WaitGroup
+
+	for _, company := range companies {
+		wg.Go(func() {
+			w.watch(ctx, scrapeCtx, company)
+		})
+	}
+
+	wg.Wait()
+
+	slog.InfoContext(ctx, "Stopped watching companies", slog.Int("companies", len(companies)))
+
+	return nil
+}
+
+// watch scrapes a single company on its schedule until ctx is done.
+func (w *Watcher) watch(ctx, scrapeCtx context.Context, company *watchlist.Company) {
+	scheduled := time.Now()
+
+	for {
+		delay := time.Until(scheduled) + jitter(company.Jitter)
+
+		slog.DebugContext(ctx, "Waiting for next scrape", slog.String("company", company.String()), slog.Duration("delay", delay))
+
+		if !sleep(ctx, delay) {
+			return
+		}
+
+		w.scrape(scrapeCtx, company)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		var skipped int
+
+		scheduled, skipped = next(company.Schedule(), scheduled, time.Now())
+		if skipped > 0 {
+			slog.WarnContext(ctx, "Skipping scheduled scrapes that overlapped the previous one",
+				slog.String("company", company.String()),
+				slog.Int("skipped", skipped),
+			)
+		}
+	}
+}
+
+// next returns the first time on the schedule after the one last scraped at that hasn't
+// already passed by now, along with how many were skipped because they had.
+func next(schedule watchlist.Schedule, last, now time.Time) (time.Time, int) {
+	skipped := 0
+
+	scheduled := schedule.Next(last)
+	for scheduled.Before(now) {
+		scheduled = schedule.Next(scheduled)
+		skipped++
+	}
+
+	return scheduled, skipped
+}
+
+// scrape scrapes and saves a company, and reports how its jobs changed. Whatever was saved
+// before a failure is still reported.
+func (w *Watcher) scrape(ctx context.Context, company *watchlist.Company) {
+	seenAt := time.Now()
+
+	// a cache that outlived the scrape would never see company info change, and would keep
+	// a failed lookup failing until the watcher is restarted
+	ctx = companyinfo.WithCache(ctx, companyinfo.New(w.companyInfo...))
+
+	slog.InfoContext(ctx, "Scraping company", slog.String("company", company.String()))
+
+	result, err := w.store.SyncCompanySeq(ctx, company.ATS, company.Slug, warnJobErrors(ctx, company.Stream(ctx)), seenAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error scraping company", slog.String("company", company.String()), slog.Any("error", err))
+	}
+
+	if result == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, changes := range []struct {
+		eventType EventType
+		jobs      []*storage.StoredJob
+	}{
+		{eventType: EventNew, jobs: result.New},
+		{eventType: EventUpdated, jobs: result.Updated},
+		{eventType: EventClosed, jobs: result.Closed},
+	} {
+		for _, job := range changes.jobs {
+			w.handle(ctx, Event{Type: changes.eventType, Source: company.ATS, Company: company.Slug, Time: seenAt, Job: job})
+		}
+	}
+}
+
+// warnJobErrors logs the jobs in a stream that failed to scrape.
+func warnJobErrors(ctx context.Context, jobs iter.Seq2[*models.Job, error]) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		for job, err := range jobs {
+			var jobErr *models.JobError
+			if errors.As(err, &jobErr) {
+				slog.WarnContext(ctx, "Error scraping job", slog.Any("error", jobErr))
+			}
+
+			if !yield(job, err) {
+				return
+			}
+		}
+	}
+}
+
+// jitter returns a random delay of up to limit.
+func jitter(limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+
+	return rand.N(limit) //nolint:gosec // scheduling jitter doesn't need a secure source
+}
+
+// sleep waits for d, and reports whether it did before ctx was done.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,205 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/atsfake"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+	"github.com/amalgamated-tools/jobscraping/pkg/storage"
+	"github.com/amalgamated-tools/jobscraping/pkg/watchlist"
+)
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	last := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec        string
+		now         time.Time
+		want        time.Time
+		wantSkipped int
+	}{
+		{spec: "1h", now: last.Add(time.Minute), want: last.Add(time.Hour), wantSkipped: 0},
+		{spec: "1h", now: last.Add(150 * time.Minute), want: last.Add(3 * time.Hour), wantSkipped: 2},
+		{spec: "0 9 * * *", now: last.Add(time.Minute), want: last.Add(24 * time.Hour), wantSkipped: 0},
+		{spec: "*/15 * * * *", now: last.Add(20 * time.Minute), want: last.Add(30 * time.Minute), wantSkipped: 1},
+	}
+
+	for _, tt := range tests {
+		schedule, err := watchlist.ParseSchedule(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q) error = %v", tt.spec, err)
+		}
+
+		got, skipped := next(schedule, last, tt.now)
+		if !got.Equal(tt.want) || skipped != tt.wantSkipped {
+			t.Errorf("next(%q) = %v, %d skipped, want %v, %d skipped", tt.spec, got, skipped, tt.want, tt.wantSkipped)
+		}
+	}
+}
+
+func TestJitter(t *testing.T) {
+	t.Parallel()
+
+	if got := jitter(0); got != 0 {
+		t.Errorf("jitter(0) = %v, want 0", got)
+	}
+
+	for range 100 {
+		if got := jitter(time.Second); got < 0 || got >= time.Second {
+			t.Fatalf("jitter(1s) = %v, want [0, 1s)", got)
+		}
+	}
+}
+
+func TestRunNoCompanies(t *testing.T) {
+	t.Parallel()
+
+	list, err := watchlist.Parse(strings.NewReader("companies: [{ats: lever, slug: acme, disabled: true}]"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	err = New(nil, list, func(context.Context, Event) {}).Run(t.Context())
+	if !errors.Is(err, ErrNoCompanies) {
+		t.Errorf("Run() error = %v, want %v", err, ErrNoCompanies)
+	}
+}
+
+// useFakeATS points the package-level HTTP client at a fake ATS server for the rest of the test.
+func useFakeATS(t *testing.T, opts ...atsfake.Option) {
+	t.Helper()
+
+	fixtures, err := atsfake.DefaultFixtures()
+	if err != nil {
+		t.Fatalf("DefaultFixtures() error = %v", err)
+	}
+
+	server := httptest.NewServer(atsfake.New(fixtures, opts...))
+	t.Cleanup(server.Close)
+
+	transport, err := atsfake.NewTransport(server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+
+	helpers.SetHTTPClient(&http.Client{Transport: transport})
+	t.Cleanup(helpers.ResetHTTPClient)
+}
+
+// openStore returns a migrated in-memory database.
+func openStore(t *testing.T) *storage.Store {
+	t.Helper()
+
+	store, err := storage.Open(t.Context(), ":memory:")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = store.Close() })
+
+	_, err = store.MigrateUp(t.Context())
+	if err != nil {
+		t.Fatalf("MigrateUp() error = %v", err)
+	}
+
+	return store
+}
+
+//nolint:paralleltest // replaces the package-level HTTP client
+func TestRun(t *testing.T) {
+	useFakeATS(t)
+
+	store := openStore(t)
+
+	// a job from an earlier scrape that is no longer listed
+	stale := models.NewJob("lever", nil)
+	stale.SourceID = "0999"
+	stale.URL = "https://jobs.lever.co/acme/0999"
+	stale.Title = "Office Manager"
+
+	_, err := store.SyncCompany(t.Context(), "lever", "acme", []*models.Job{stale}, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("SyncCompany() error = %v", err)
+	}
+
+	list, err := watchlist.Parse(strings.NewReader("companies: [{ats: lever, slug: acme, every: 1h}]"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	events := make([]Event, 0)
+	handle := func(_ context.Context, event Event) {
+		events = append(events, event)
+
+		// the first scrape reports every fixture job as new and the stale one as closed
+		if len(events) == 4 {
+			cancel()
+		}
+	}
+
+	err = New(store, list, handle).Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	counts := map[EventType]int{}
+	for _, event := range events {
+		counts[event.Type]++
+
+		if event.Source != "lever" || event.Company != "acme" {
+			t.Errorf("Run() event for %s/%s, want lever/acme", event.Source, event.Company)
+		}
+	}
+
+	if counts[EventNew] != 3 || counts[EventClosed] != 1 || events[3].Job.Job.SourceID != "0999" {
+		t.Errorf("Run() events = %v, want 3 new jobs and the stale one closed", counts)
+	}
+}
+
+//nolint:paralleltest // replaces the package-level HTTP client
+func TestRunRetriesCompanyInfo(t *testing.T) {
+	// only the first request, the company info lookup of the first scrape, fails
+	useFakeATS(t, atsfake.WithFaults(atsfake.Fault{Path: "/ashby/api/non-user-graphql", Every: 1000, Status: http.StatusNotFound}))
+
+	store := openStore(t)
+
+	list, err := watchlist.Parse(strings.NewReader("companies: [{ats: ashby, slug: acme, every: 100ms}]"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	events := make([]Event, 0)
+	handle := func(_ context.Context, event Event) {
+		events = append(events, event)
+
+		// Ashby gives up on a company whose info can't be looked up, so the jobs only
+		// arrive once a later scrape looks it up again
+		if len(events) == 3 {
+			cancel()
+		}
+	}
+
+	err = New(store, list, handle).Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(events) != 3 || events[0].Type != EventNew || events[0].Job.Job.Company.Name != "Acme Corporation" {
+		t.Errorf("Run() events = %v, want 3 new jobs at Acme Corporation after the failed lookup", events)
+	}
+}
@@ -0,0 +1,50 @@
+package watchlist
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultEvery is how often companies are scraped when neither they nor the watchlist
+// give a schedule.
+const DefaultEvery = "6h"
+
+// ErrInvalidSchedule is returned for a schedule that is neither a positive duration nor a
+// cron expression.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule gives the times a company is scraped at.
+type Schedule interface {
+	// Next returns the first time after t that the company should be scraped.
+	Next(t time.Time) time.Time
+}
+
+// interval is a Schedule that repeats at a fixed duration.
+type interval time.Duration
+
+func (i interval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+// ParseSchedule parses a duration such as 6h, or a standard five field cron expression
+// such as "0 9 * * 1-5" or a descriptor such as @daily.
+func ParseSchedule(spec string) (Schedule, error) {
+	every, err := time.ParseDuration(spec)
+	if err == nil {
+		if every <= 0 {
+			return nil, fmt.Errorf("%w: %q must be positive", ErrInvalidSchedule, spec)
+		}
+
+		return interval(every), nil
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is neither a duration nor a cron expression: %w", ErrInvalidSchedule, spec, err)
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,278 @@
+// Package watchlist reads the list of companies to scrape from a YAML file, such as:
+//
+//	every: 6h
+//	jitter: 5m
+//	companies:
+//	  - ats: greenhouse
+//	    slug: acme
+//	    name: Acme Corporation
+//	    every: "0 9 * * 1-5"
+//	  - url: https://jobs.eu.lever.co/globex
+//	    workers: 2
+//	    timeout: 2m
+//	  - ats: ashby
+//	    slug: initech
+//	    disabled: true
+//	  - ats: teamtailor
+//	    slug: umbrella
+//	    api_key: $UMBRELLA_TEAMTAILOR_KEY
+//
+// Each company is given either by its ATS and board slug, or by the URL of its board,
+// which also picks the right API for boards such as Lever's EU ones. The top-level every
+// and jitter are the defaults for companies that don't set their own.
+package watchlist
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/models"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+)
+
+var (
+	// ErrInvalidCompany is returned when a company in the watchlist can't be scraped as given.
+	ErrInvalidCompany = errors.New("invalid company")
+	// ErrDuplicateCompany is returned when a company is listed more than once.
+	ErrDuplicateCompany = errors.New("company listed more than once")
+)
+
+// Watchlist is the list of companies to scrape.
+type Watchlist struct {
+	// Every is the default schedule, DefaultEvery if empty.
+	Every string `yaml:"every"`
+	// Jitter is the default jitter.
+	Jitter time.Duration `yaml:"jitter"`
+
+	Companies []*Company `yaml:"companies"`
+}
+
+// Company is a single company to scrape, along with its options.
+type Company struct {
+	// ATS is the name of the loader for the company, e.g. greenhouse.
+	ATS string `yaml:"ats"`
+	// Slug is the company's board slug on the ATS.
+	Slug string `yaml:"slug"`
+	// URL is the company's board, in place of ATS and Slug.
+	URL string `yaml:"url"`
+	// Name replaces the company name scraped from the ATS when set.
+	Name string `yaml:"name"`
+	// Workers is the number of jobs to fetch concurrently, or zero for the default.
+	Workers int `yaml:"workers"`
+	// Timeout bounds the scrape of the company, or zero for no limit.
+	Timeout time.Duration `yaml:"timeout"`
+	// APIKey is the company's API key on an ATS that takes one, such as Teamtailor.
+	// Environment variables in it are expanded, so the key can be kept out of the file.
+	APIKey string `yaml:"api_key"`
+	// Disabled leaves the company out of scrapes without removing it from the list.
+	Disabled bool `yaml:"disabled"`
+	// Every is how often the company is watched: a duration such as 6h or a cron expression
+	// such as "0 9 * * 1-5". See ParseSchedule.
+	Every string `yaml:"every"`
+	// Jitter delays each scheduled scrape by up to this long, so that companies on the same
+	// schedule don't all start at once.
+	Jitter time.Duration `yaml:"jitter"`
+
+	target   ats.Target
+	schedule Schedule
+}
+
+// Load reads a watchlist from a YAML file.
+func Load(path string) (*Watchlist, error) {
+	file, err := os.Open(path) //nolint:gosec // the path is chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("error opening watchlist: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return Parse(file)
+}
+
+// Parse reads a watchlist from YAML and checks that every company in it can be scraped.
+// Unknown keys are rejected so that typos in options don't go unnoticed.
+func Parse(r io.Reader) (*Watchlist, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	list := &Watchlist{}
+
+	err := decoder.Decode(list)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing watchlist: %w", err)
+	}
+
+	seen := make(map[string]int, len(list.Companies))
+
+	for i, company := range list.Companies {
+		if company == nil {
+			return nil, fmt.Errorf("companies[%d]: %w: empty entry", i, ErrInvalidCompany)
+		}
+
+		if company.Every == "" {
+			company.Every = cmp.Or(list.Every, DefaultEvery)
+		}
+
+		if company.Jitter == 0 {
+			company.Jitter = list.Jitter
+		}
+
+		err = company.resolve()
+		if err != nil {
+			return nil, fmt.Errorf("companies[%d]: %w", i, err)
+		}
+
+		key := company.String()
+		if first, ok := seen[key]; ok {
+			return nil, fmt.Errorf("companies[%d]: %w: %s, first at companies[%d]", i, ErrDuplicateCompany, key, first)
+		}
+
+		seen[key] = i
+	}
+
+	return list, nil
+}
+
+// Enabled returns the companies that aren't disabled.
+func (w *Watchlist) Enabled() []*Company {
+	enabled := make([]*Company, 0, len(w.Companies))
+
+	for _, company := range w.Companies {
+		if !company.Disabled {
+			enabled = append(enabled, company)
+		}
+	}
+
+	return enabled
+}
+
+// resolve fills in the ATS and slug from the URL, or the target from the ATS and slug, and
+// checks the options and schedule.
+func (c *Company) resolve() error {
+	switch {
+	case c.URL != "" && (c.ATS != "" || c.Slug != ""):
+		return fmt.Errorf("%w: give either url or ats and slug, not both", ErrInvalidCompany)
+	case c.URL != "":
+		target, err := ats.ParseURL(c.URL)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidCompany, err)
+		}
+
+		c.target = target
+		c.target.JobID = ""
+		c.ATS = target.ATS
+		c.Slug = target.Company
+	case c.ATS == "" || c.Slug == "":
+		return fmt.Errorf("%w: ats and slug are required", ErrInvalidCompany)
+	default:
+		c.ATS = strings.ToLower(strings.TrimSpace(c.ATS))
+		c.target = ats.Target{ATS: c.ATS, Company: c.Slug}
+	}
+
+	if c.APIKey != "" && os.ExpandEnv(c.APIKey) == "" {
+		return fmt.Errorf("%w: api_key %s is empty", ErrInvalidCompany, c.APIKey)
+	}
+
+	_, err := c.loader()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCompany, err)
+	}
+
+	if c.Workers < 0 {
+		return fmt.Errorf("%w: workers must not be negative", ErrInvalidCompany)
+	}
+
+	if c.Timeout < 0 {
+		return fmt.Errorf("%w: timeout must not be negative", ErrInvalidCompany)
+	}
+
+	if c.Jitter < 0 {
+		return fmt.Errorf("%w: jitter must not be negative", ErrInvalidCompany)
+	}
+
+	c.schedule, err = ParseSchedule(c.Every)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCompany, err)
+	}
+
+	return nil
+}
+
+// Schedule returns when the company is to be scraped, as given by Every.
+func (c *Company) Schedule() Schedule {
+	return c.schedule
+}
+
+// String returns the company as ats/slug.
+func (c *Company) String() string {
+	return c.ATS + "/" + strings.ToLower(c.Slug)
+}
+
+// Stream scrapes all jobs for the company with its options applied, yielding each one as
+// it arrives. Failed jobs are yielded as a *models.JobError; any other error ends the stream.
+func (c *Company) Stream(ctx context.Context) iter.Seq2[*models.Job, error] {
+	return func(yield func(*models.Job, error) bool) {
+		loader, err := c.loader()
+		if err != nil {
+			yield(nil, fmt.Errorf("error looking up loader: %w", err))
+			return
+		}
+
+		// the stream can be run more than once, so ctx itself is left alone
+		scrapeCtx := ctx
+
+		if c.Workers > 0 {
+			scrapeCtx = helpers.WithConcurrency(scrapeCtx, c.Workers)
+		}
+
+		if c.Timeout > 0 {
+			var cancel context.CancelFunc
+
+			scrapeCtx, cancel = context.WithTimeout(scrapeCtx, c.Timeout)
+			defer cancel()
+		}
+
+		for job, err := range ats.Stream(scrapeCtx, loader, c.Slug) {
+			if job != nil && c.Name != "" {
+				c.rename(job)
+			}
+
+			if !yield(job, err) {
+				return
+			}
+		}
+	}
+}
+
+// loader returns the loader the company is scraped with, given its API key if it has one.
+func (c *Company) loader() (ats.Loader, error) {
+	if c.APIKey == "" {
+		return c.target.Loader() //nolint:wrapcheck // the callers wrap it
+	}
+
+	return ats.KeyedLoader(c.target.ATS, c.target.Company, os.ExpandEnv(c.APIKey)) //nolint:wrapcheck // the callers wrap it
+}
+
+// rename overrides the job's company name. The company is copied first since it may be
+// shared with other jobs and with the company info cache.
+func (c *Company) rename(job *models.Job) {
+	company := models.NewCompany()
+	if job.Company != nil {
+		*company = *job.Company
+	}
+
+	company.Name = c.Name
+	job.Company = company
+}
@@ -0,0 +1,162 @@
+package watchlist
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amalgamated-tools/jobscraping/pkg/ats"
+	"github.com/amalgamated-tools/jobscraping/pkg/ats/teamtailor"
+	"github.com/amalgamated-tools/jobscraping/pkg/atsfake"
+	"github.com/amalgamated-tools/jobscraping/pkg/helpers"
+)
+
+const testWatchlist = `
+every: 30m
+jitter: 1m
+companies:
+  - ats: Greenhouse
+    slug: acme
+    name: Acme Corp
+  - url: https://jobs.eu.lever.co/globex/c4b4c0a6-8d1e-4e5f-9a3b-2f6d7e8a9b0c
+    workers: 2
+    timeout: 2m
+    every: "0 9 * * 1-5"
+  - ats: ashby
+    slug: initech
+    disabled: true
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	list, err := Parse(strings.NewReader(testWatchlist))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(list.Companies) != 3 {
+		t.Fatalf("Parse() companies = %d, want 3", len(list.Companies))
+	}
+
+	acme := list.Companies[0]
+	if acme.ATS != "greenhouse" || acme.Slug != "acme" || acme.Name != "Acme Corp" {
+		t.Errorf("Parse() companies[0] = %+v, want greenhouse/acme named Acme Corp", acme)
+	}
+
+	globex := list.Companies[1]
+	if globex.ATS != "lever" || globex.Slug != "globex" || globex.Workers != 2 || globex.Timeout != 2*time.Minute {
+		t.Errorf("Parse() companies[1] = %+v, want lever/globex with 2 workers and a 2m timeout", globex)
+	}
+
+	if acme.Every != "30m" || acme.Jitter != time.Minute || globex.Every != "0 9 * * 1-5" {
+		t.Errorf("Parse() schedules = %q with %v jitter, %q, want the watchlist defaults and globex's own", acme.Every, acme.Jitter, globex.Every)
+	}
+
+	monday := time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC)
+	if got := globex.Schedule().Next(monday); !got.Equal(monday.Add(23 * time.Hour)) {
+		t.Errorf("Schedule().Next(%v) = %v, want 9am the next day", monday, got)
+	}
+
+	enabled := list.Enabled()
+	if len(enabled) != 2 || enabled[0] != acme || enabled[1] != globex {
+		t.Errorf("Enabled() = %v, want acme and globex", enabled)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		yaml string
+		want error
+	}{
+		{name: "unknown ATS", yaml: "companies: [{ats: nope, slug: acme}]", want: ats.ErrUnknownATS},
+		{name: "missing slug", yaml: "companies: [{ats: lever}]", want: ErrInvalidCompany},
+		{name: "url and slug", yaml: "companies: [{url: 'https://jobs.lever.co/acme', slug: acme}]", want: ErrInvalidCompany},
+		{name: "unsupported url", yaml: "companies: [{url: 'https://example.com/careers'}]", want: ats.ErrUnsupportedURL},
+		{name: "negative workers", yaml: "companies: [{ats: lever, slug: acme, workers: -1}]", want: ErrInvalidCompany},
+		{name: "bad schedule", yaml: "companies: [{ats: lever, slug: acme, every: often}]", want: ErrInvalidSchedule},
+		{name: "zero interval", yaml: "every: 0s\ncompanies: [{ats: lever, slug: acme}]", want: ErrInvalidSchedule},
+		{name: "key for an ATS without one", yaml: "companies: [{ats: lever, slug: acme, api_key: secret}]", want: ats.ErrAPIKeyUnsupported},
+		{name: "unset key variable", yaml: "companies: [{ats: teamtailor, slug: acme, api_key: $JOBSCRAPING_UNSET_KEY}]", want: ErrInvalidCompany},
+		{name: "duplicate", yaml: "companies: [{ats: lever, slug: acme}, {url: 'https://jobs.lever.co/ACME'}]", want: ErrDuplicateCompany},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.yaml))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Parse() %s error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	_, err := Parse(strings.NewReader("companies: [{ats: lever, slug: acme, wrokers: 2}]"))
+	if err == nil {
+		t.Error("Parse() with an unknown key error = nil, want an error")
+	}
+}
+
+//nolint:paralleltest // sets an environment variable
+func TestCompanyAPIKey(t *testing.T) {
+	t.Setenv("UMBRELLA_TEAMTAILOR_KEY", "public-key")
+
+	list, err := Parse(strings.NewReader("companies: [{ats: teamtailor, slug: umbrella, api_key: $UMBRELLA_TEAMTAILOR_KEY}]"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	loader, err := list.Companies[0].loader()
+	if err != nil {
+		t.Fatalf("loader() error = %v", err)
+	}
+
+	if loader != teamtailor.New(teamtailor.WithAPIKey("umbrella", "public-key")) {
+		t.Errorf("loader() = %+v, want a loader with the key from the environment", loader)
+	}
+}
+
+//nolint:paralleltest // replaces the package-level HTTP client
+func TestCompanyStream(t *testing.T) {
+	fixtures, err := atsfake.DefaultFixtures()
+	if err != nil {
+		t.Fatalf("DefaultFixtures() error = %v", err)
+	}
+
+	server := httptest.NewServer(atsfake.New(fixtures))
+	t.Cleanup(server.Close)
+
+	transport, err := atsfake.NewTransport(server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+
+	helpers.SetHTTPClient(&http.Client{Transport: transport})
+	t.Cleanup(helpers.ResetHTTPClient)
+
+	list, err := Parse(strings.NewReader("companies: [{ats: greenhouse, slug: globex, name: Globex Corporation}]"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	count := 0
+
+	for job, err := range list.Companies[0].Stream(t.Context()) {
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+
+		count++
+
+		if job.Company == nil || job.Company.Name != "Globex Corporation" {
+			t.Errorf("Stream() company = %+v, want the name override", job.Company)
+		}
+	}
+
+	if count != 1 {
+		t.Errorf("Stream() = %d jobs, want 1", count)
+	}
+}